@@ -1,101 +1,202 @@
 package main
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"log"
 	"net/http"
 	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
 
+	"job-search-backend/internal/alerts"
+	"job-search-backend/internal/config"
 	"job-search-backend/internal/database"
 	"job-search-backend/internal/handlers"
-	"job-search-backend/internal/middleware"
+	"job-search-backend/internal/mail"
+	"job-search-backend/internal/migrate"
+	"job-search-backend/internal/notifications"
+	"job-search-backend/internal/repository"
+	"job-search-backend/internal/scheduler"
+	"job-search-backend/internal/server"
+	"job-search-backend/internal/storage"
+	"job-search-backend/internal/utils"
 
-	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
-	"github.com/joho/godotenv"
 )
 
 func main() {
-	// Load environment variables
-	if err := godotenv.Load(); err != nil {
-		log.Println("No .env file found")
+	// Maintenance commands; without arguments the server starts
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "migrate":
+			runMigrate(os.Args[2:])
+		case "config":
+			runConfig(os.Args[2:])
+		default:
+			log.Fatalf("unknown command %q, expected migrate or config", os.Args[1])
+		}
+		return
 	}
 
-	// Connect to database
-	database.Connect()
+	cfg := loadConfig()
+	gin.SetMode(cfg.Server.Mode)
+	utils.SetJWTSecret(cfg.Auth.JWTSecret)
+
+	// Cancelled on SIGINT or SIGTERM to start a graceful shutdown
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	// Connect to database and apply pending migrations
+	database.Connect(cfg.Database)
 	database.Migrate()
 
-	// Initialize Gin router
-	r := gin.Default()
-
-	// CORS configuration
-	config := cors.DefaultConfig()
-	config.AllowOrigins = []string{"*"}
-	config.AllowMethods = []string{"GET", "POST", "PUT", "DELETE", "OPTIONS", "TRACE"}
-	config.AllowHeaders = []string{"Origin", "Content-Type", "Accept", "Authorization"}
-	config.AllowCredentials = true
-	r.Use(cors.New(config))
-
-	// Add TRACE method support
-	r.Handle("TRACE", "/*path", func(c *gin.Context) {
-		c.Header("Allow", "GET, POST, PUT, DELETE, OPTIONS")
-		c.JSON(http.StatusMethodNotAllowed, gin.H{"error": "Method Not Allowed"})
-	})
+	// Readiness probe: database ping and schema version
+	sqlDB, err := database.DB.DB()
+	if err != nil {
+		log.Fatal("Failed to get the connection pool:", err)
+	}
+	migrator, err := migrate.New(database.DB)
+	if err != nil {
+		log.Fatal("Failed to load migrations:", err)
+	}
+	health := &handlers.HealthHandler{DB: sqlDB, Migrations: migrator}
 
-	// Initialize handlers
-	authHandler := &handlers.AuthHandler{}
-	jobHandler := &handlers.JobHandler{}
-	applicationHandler := &handlers.ApplicationHandler{}
-
-	// Public routes
-	api := r.Group("/api")
-	{
-		// Auth routes
-		auth := api.Group("/auth")
-		{
-			auth.POST("/register", authHandler.Register)
-			auth.POST("/login", authHandler.Login)
-		}
+	// File storage for uploaded resumes
+	fileStorage, err := storage.New(cfg.Storage)
+	if err != nil {
+		log.Fatal("Failed to initialize storage:", err)
+	}
 
-		// Public job routes
-		jobs := api.Group("/jobs")
-		{
-			jobs.GET("", jobHandler.GetJobs)
-			jobs.GET("/:id", jobHandler.GetJob)
-		}
+	// Outbound email
+	mailer, err := mail.New(cfg.Mail)
+	if err != nil {
+		log.Fatal("Failed to initialize mailer:", err)
 	}
 
-	// Protected routes
-	protected := api.Group("")
-	protected.Use(middleware.AuthMiddleware())
-	{
-		// User profile
-		protected.GET("/profile", authHandler.GetProfile)
+	// Actions unverified accounts may not do: "login", "apply"
+	requireVerified := map[string]bool{}
+	for _, action := range cfg.Auth.EmailVerificationRequiredFor {
+		requireVerified[action] = true
+	}
 
-		// Job management (employers)
-		protected.POST("/jobs", middleware.EmployerMiddleware(), jobHandler.CreateJob)
-		protected.PUT("/jobs/:id", jobHandler.UpdateJob)
-		protected.DELETE("/jobs/:id", jobHandler.DeleteJob)
+	// In-app notifications, pushed live to open event streams
+	notificationService := notifications.NewService()
+
+	// Background workers stop when ctx is cancelled
+	var workers sync.WaitGroup
+	runWorker := func(run func(context.Context)) {
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			run(ctx)
+		}()
+	}
 
-		// Applications
-		protected.POST("/applications", applicationHandler.CreateApplication)
-		protected.GET("/applications/my", applicationHandler.GetUserApplications)
-		protected.GET("/applications/employer", middleware.EmployerMiddleware(), applicationHandler.GetEmployerApplications)
-		protected.GET("/applications/job/:jobId", applicationHandler.GetJobApplications)
-		protected.PUT("/applications/:id/status", middleware.EmployerMiddleware(), applicationHandler.UpdateApplicationStatus)
+	// Saved search alerts
+	alertWorker := &alerts.Worker{
+		Notifiers: []alerts.Notifier{
+			alerts.InAppNotifier{Service: notificationService},
+			alerts.EmailNotifier{Mailer: mailer, AppURL: cfg.Server.FrontendURL},
+		},
+		Interval: cfg.Workers.AlertsInterval,
+	}
+	runWorker(alertWorker.Run)
 
-		// Admin routes
-		protected.GET("/applications/all", middleware.AdminMiddleware(), applicationHandler.GetAllApplications)
-		protected.GET("/jobs/all", middleware.AdminMiddleware(), jobHandler.GetAllJobs)
+	// Publishes scheduled jobs and closes expired ones
+	jobScheduler := &scheduler.Scheduler{
+		Notifications: notificationService,
+		Interval:      cfg.Workers.JobSchedulerInterval,
 	}
+	runWorker(jobScheduler.Run)
+
+	// HTTP API
+	r := server.NewRouter(server.Options{
+		Jobs:                 repository.NewJobRepository(database.DB),
+		Applications:         repository.NewApplicationRepository(database.DB),
+		Users:                repository.NewUserRepository(database.DB),
+		Mailer:               mailer,
+		Storage:              fileStorage,
+		Notifications:        notificationService,
+		Health:               health,
+		AppURL:               cfg.Server.FrontendURL,
+		RequireVerifiedEmail: requireVerified,
+		MaxResumeSize:        cfg.Storage.MaxResumeSize,
+	})
 
-	// Start server
-	port := "8080"
-	if envPort := os.Getenv("PORT"); envPort != "" {
-		port = envPort
+	srv := &http.Server{
+		Addr:              fmt.Sprintf(":%d", cfg.Server.Port),
+		Handler:           r,
+		ReadHeaderTimeout: cfg.Server.ReadHeaderTimeout,
+		ReadTimeout:       cfg.Server.ReadTimeout,
+		WriteTimeout:      cfg.Server.WriteTimeout,
+		IdleTimeout:       cfg.Server.IdleTimeout,
 	}
+	// Notification streams never finish on their own
+	srv.RegisterOnShutdown(notificationService.Hub.Close)
 
-	log.Printf("Server starting on port %s", port)
-	if err := r.Run(":" + port); err != nil {
+	// Start server
+	serverErr := make(chan error, 1)
+	go func() {
+		log.Printf("Server starting on port %d", cfg.Server.Port)
+		serverErr <- srv.ListenAndServe()
+	}()
+
+	select {
+	case err := <-serverErr:
 		log.Fatal("Failed to start server:", err)
+	case <-ctx.Done():
+	}
+	// A second signal kills the process immediately
+	stop()
+
+	log.Println("Shutting down")
+	health.Drain()
+	time.Sleep(cfg.Server.ShutdownDelay)
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.Server.ShutdownTimeout)
+	defer cancel()
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		log.Println("Failed to finish in-flight requests:", err)
+	}
+	if err := <-serverErr; err != nil && !errors.Is(err, http.ErrServerClosed) {
+		log.Println("Server error:", err)
+	}
+
+	workers.Wait()
+	if err := database.Close(); err != nil {
+		log.Println("Failed to close the database:", err)
+	}
+	log.Println("Server stopped")
+}
+
+// loadConfig loads and validates the configuration, exiting on errors.
+func loadConfig() *config.Config {
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatal(err)
+	}
+	return cfg
+}
+
+// runConfig implements the config subcommand. `config print` shows the
+// effective configuration with secrets redacted, then reports whether it is
+// valid.
+func runConfig(args []string) {
+	if len(args) != 1 || args[0] != "print" {
+		log.Fatal("usage: main config print")
+	}
+	cfg, err := config.Read()
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := config.Print(os.Stdout, cfg); err != nil {
+		log.Fatal(err)
+	}
+	if err := cfg.Validate(); err != nil {
+		log.Fatal(err)
 	}
 }
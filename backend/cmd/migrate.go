@@ -0,0 +1,97 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"text/tabwriter"
+
+	"job-search-backend/internal/database"
+	"job-search-backend/internal/migrate"
+)
+
+const migrateUsage = `usage: main migrate <command>
+
+  up                 apply every pending migration
+  down [n]           revert the last n applied migrations (default 1)
+  status             list the migrations and when they were applied
+  create [-dir d] <name>
+                     add an empty up/down pair to the migrations directory`
+
+// runMigrate implements the migrate subcommand.
+func runMigrate(args []string) {
+	if len(args) == 0 {
+		log.Fatal(migrateUsage)
+	}
+	ctx := context.Background()
+
+	switch command, args := args[0], args[1:]; command {
+	case "up":
+		database.Connect(loadConfig().Database)
+		database.Migrate()
+
+	case "down":
+		steps := 1
+		if len(args) > 0 {
+			n, err := strconv.Atoi(args[0])
+			if err != nil || n < 1 {
+				log.Fatal("down: the number of migrations must be a positive integer")
+			}
+			steps = n
+		}
+		database.Connect(loadConfig().Database)
+		reverted, err := newMigrator().Down(ctx, steps)
+		for _, migration := range reverted {
+			log.Printf("Reverted migration %s", migration)
+		}
+		if err != nil {
+			log.Fatal("Failed to revert migrations:", err)
+		}
+
+	case "status":
+		database.Connect(loadConfig().Database)
+		list, err := newMigrator().Status(ctx)
+		if err != nil {
+			log.Fatal("Failed to read migration status:", err)
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "VERSION\tNAME\tAPPLIED AT")
+		for _, status := range list {
+			applied := "pending"
+			if status.AppliedAt != nil {
+				applied = status.AppliedAt.Local().Format("2006-01-02 15:04:05")
+			}
+			fmt.Fprintf(w, "%04d\t%s\t%s\n", status.Version, status.Name, applied)
+		}
+		w.Flush()
+
+	case "create":
+		flags := flag.NewFlagSet("create", flag.ExitOnError)
+		dir := flags.String("dir", "migrations", "directory holding the migration files")
+		flags.Parse(args)
+		if flags.NArg() != 1 {
+			log.Fatal(migrateUsage)
+		}
+		paths, err := migrate.Create(*dir, flags.Arg(0))
+		if err != nil {
+			log.Fatal("Failed to create migration:", err)
+		}
+		for _, path := range paths {
+			fmt.Println(path)
+		}
+
+	default:
+		log.Fatal(migrateUsage)
+	}
+}
+
+func newMigrator() *migrate.Migrator {
+	migrator, err := migrate.New(database.DB)
+	if err != nil {
+		log.Fatal("Failed to load migrations:", err)
+	}
+	return migrator
+}
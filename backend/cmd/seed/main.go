@@ -4,94 +4,36 @@ import (
 	"log"
 	"time"
 
+	"job-search-backend/internal/config"
+	"job-search-backend/internal/database"
+	"job-search-backend/internal/models"
+
 	"golang.org/x/crypto/bcrypt"
-	"gorm.io/driver/postgres"
-	"gorm.io/gorm"
-	"gorm.io/gorm/logger"
 )
 
-// Simple models for seeding
-type User struct {
-	ID        uint   `gorm:"primaryKey"`
-	Email     string `gorm:"unique;not null"`
-	Password  string `gorm:"not null"`
-	Name      string `gorm:"not null"`
-	Role      string `gorm:"default:'job_seeker'"`
-	CreatedAt time.Time
-	UpdatedAt time.Time
-	DeletedAt gorm.DeletedAt `gorm:"index"`
-}
-
-type UserProfile struct {
-	ID         uint `gorm:"primaryKey"`
-	UserID     uint `gorm:"not null"`
-	Phone      string
-	Location   string
-	Experience string
-	Skills     string
-	Education  string
-	Resume     string
-	CreatedAt  time.Time
-	UpdatedAt  time.Time
-	DeletedAt  gorm.DeletedAt `gorm:"index"`
-}
-
-type Job struct {
-	ID           uint   `gorm:"primaryKey"`
-	Title        string `gorm:"not null"`
-	Description  string `gorm:"type:text"`
-	Company      string `gorm:"not null"`
-	Location     string
-	Salary       string
-	Type         string
-	Category     string
-	Requirements string `gorm:"type:text"`
-	Benefits     string `gorm:"type:text"`
-	EmployerID   uint   `gorm:"not null"`
-	IsActive     bool   `gorm:"default:true"`
-	CreatedAt    time.Time
-	UpdatedAt    time.Time
-	DeletedAt    gorm.DeletedAt `gorm:"index"`
-}
-
-type JobApplication struct {
-	ID        uint   `gorm:"primaryKey"`
-	JobID     uint   `gorm:"not null"`
-	UserID    uint   `gorm:"not null"`
-	Status    string `gorm:"default:'pending'"`
-	Message   string `gorm:"type:text"`
-	CreatedAt time.Time
-	UpdatedAt time.Time
-	DeletedAt gorm.DeletedAt `gorm:"index"`
+func intPtr(v int) *int {
+	return &v
 }
 
 func main() {
-	// Connect to database
-	dsn := "host=postgres user=postgres password=password dbname=jobsearch port=5432 sslmode=disable"
-	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{
-		Logger: logger.Default.LogMode(logger.Info),
-	})
+	cfg, err := config.Load()
 	if err != nil {
-		log.Fatal("Failed to connect to database:", err)
+		log.Fatal(err)
 	}
 
-	log.Println("Database connected successfully")
+	// Connect to database and bring the schema up to date the same way the server does
+	database.Connect(cfg.Database)
+	database.Migrate()
+	db := database.DB
 
-	// Auto migrate
-	db.AutoMigrate(&User{}, &UserProfile{}, &Job{}, &JobApplication{})
-
-	// Clear existing data
+	// Clear existing data and reset auto-increment sequences; reference data
+	// (categories, employment types) and the migration history are kept
 	log.Println("Clearing existing data...")
-	db.Exec("DELETE FROM job_applications")
-	db.Exec("DELETE FROM jobs")
-	db.Exec("DELETE FROM user_profiles")
-	db.Exec("DELETE FROM users")
-
-	// Reset auto-increment sequences
-	db.Exec("ALTER SEQUENCE users_id_seq RESTART WITH 1")
-	db.Exec("ALTER SEQUENCE user_profiles_id_seq RESTART WITH 1")
-	db.Exec("ALTER SEQUENCE jobs_id_seq RESTART WITH 1")
-	db.Exec("ALTER SEQUENCE job_applications_id_seq RESTART WITH 1")
+	if err := db.Exec(`TRUNCATE users, user_profiles, companies, company_members, jobs, job_applications,
+		application_status_events, application_messages, message_attachments, interviews, interview_interviewers, refresh_tokens,
+		role_changes, action_tokens, saved_jobs, saved_searches, notifications RESTART IDENTITY CASCADE`).Error; err != nil {
+		log.Fatal("Failed to clear existing data:", err)
+	}
 
 	// Hash password for all users
 	hashedPassword, err := bcrypt.GenerateFromPassword([]byte("password123"), bcrypt.DefaultCost)
@@ -99,44 +41,51 @@ func main() {
 		log.Fatal("Failed to hash password:", err)
 	}
 
-	// Create test users
+	// Create test users; their addresses count as verified
 	log.Println("Creating test users...")
-	users := []User{
+	verifiedAt := time.Now()
+	users := []models.User{
 		{
-			Email:    "admin@example.com",
-			Password: string(hashedPassword),
-			Name:     "Администратор",
-			Role:     "admin",
+			Email:           "admin@example.com",
+			Password:        string(hashedPassword),
+			Name:            "Администратор",
+			Role:            models.RoleAdmin,
+			EmailVerifiedAt: &verifiedAt,
 		},
 		{
-			Email:    "employer1@company.com",
-			Password: string(hashedPassword),
-			Name:     "Иван Петров",
-			Role:     "employer",
+			Email:           "employer1@company.com",
+			Password:        string(hashedPassword),
+			Name:            "Иван Петров",
+			Role:            models.RoleEmployer,
+			EmailVerifiedAt: &verifiedAt,
 		},
 		{
-			Email:    "employer2@tech.com",
-			Password: string(hashedPassword),
-			Name:     "Мария Сидорова",
-			Role:     "employer",
+			Email:           "employer2@tech.com",
+			Password:        string(hashedPassword),
+			Name:            "Мария Сидорова",
+			Role:            models.RoleEmployer,
+			EmailVerifiedAt: &verifiedAt,
 		},
 		{
-			Email:    "jobseeker1@email.com",
-			Password: string(hashedPassword),
-			Name:     "Алексей Козлов",
-			Role:     "job_seeker",
+			Email:           "jobseeker1@email.com",
+			Password:        string(hashedPassword),
+			Name:            "Алексей Козлов",
+			Role:            models.RoleJobSeeker,
+			EmailVerifiedAt: &verifiedAt,
 		},
 		{
-			Email:    "jobseeker2@email.com",
-			Password: string(hashedPassword),
-			Name:     "Елена Волкова",
-			Role:     "job_seeker",
+			Email:           "jobseeker2@email.com",
+			Password:        string(hashedPassword),
+			Name:            "Елена Волкова",
+			Role:            models.RoleJobSeeker,
+			EmailVerifiedAt: &verifiedAt,
 		},
 		{
-			Email:    "jobseeker3@email.com",
-			Password: string(hashedPassword),
-			Name:     "Дмитрий Морозов",
-			Role:     "job_seeker",
+			Email:           "jobseeker3@email.com",
+			Password:        string(hashedPassword),
+			Name:            "Дмитрий Морозов",
+			Role:            models.RoleJobSeeker,
+			EmailVerifiedAt: &verifiedAt,
 		},
 	}
 
@@ -148,7 +97,7 @@ func main() {
 
 	// Create user profiles
 	log.Println("Creating user profiles...")
-	profiles := []UserProfile{
+	profiles := []models.UserProfile{
 		{
 			UserID:     1,
 			Phone:      "+7 (999) 123-45-67",
@@ -213,15 +162,18 @@ func main() {
 
 	// Create test jobs
 	log.Println("Creating test jobs...")
-	jobs := []Job{
+	jobs := []models.Job{
 		{
 			Title:        "Senior Go Developer",
 			Description:  "Ищем опытного Go разработчика для работы над высоконагруженными системами. Проект связан с финтехом, работа в команде из 5-7 человек.",
 			Company:      "FinTech Solutions",
 			Location:     "Москва",
-			Salary:       "200000-300000 руб.",
+			SalaryMin:    intPtr(200000),
+			SalaryMax:    intPtr(300000),
+			Currency:     "RUB",
+			SalaryPeriod: "month",
 			Type:         "full-time",
-			Category:     "IT",
+			Category:     "it",
 			Requirements: "• Опыт работы с Go от 3 лет\n• Знание PostgreSQL, Redis\n• Опыт работы с Docker, Kubernetes\n• Понимание микросервисной архитектуры\n• Опыт работы с gRPC, REST API",
 			Benefits:     "• Конкурентная зарплата\n• Медицинская страховка\n• Гибкий график работы\n• Возможность удаленной работы\n• Обучение и конференции",
 			EmployerID:   2,
@@ -232,9 +184,12 @@ func main() {
 			Description:  "Развиваем платформу для онлайн-обучения. Нужен React разработчик для создания пользовательских интерфейсов.",
 			Company:      "EduTech Startup",
 			Location:     "Санкт-Петербург",
-			Salary:       "150000-250000 руб.",
+			SalaryMin:    intPtr(150000),
+			SalaryMax:    intPtr(250000),
+			Currency:     "RUB",
+			SalaryPeriod: "month",
 			Type:         "full-time",
-			Category:     "IT",
+			Category:     "it",
 			Requirements: "• Опыт работы с React от 2 лет\n• Знание TypeScript, Redux\n• Опыт работы с Material-UI или аналогичными библиотеками\n• Понимание принципов UX/UI\n• Опыт работы с REST API",
 			Benefits:     "• Работа в стартапе с быстрым ростом\n• Опционы в компании\n• Современный офис в центре города\n• Команда молодых профессионалов",
 			EmployerID:   3,
@@ -245,9 +200,12 @@ func main() {
 			Description:  "Создаем новый продукт в сфере e-commerce. Ищем талантливого дизайнера для создания пользовательских интерфейсов.",
 			Company:      "ShopTech",
 			Location:     "Москва",
-			Salary:       "120000-180000 руб.",
+			SalaryMin:    intPtr(120000),
+			SalaryMax:    intPtr(180000),
+			Currency:     "RUB",
+			SalaryPeriod: "month",
 			Type:         "full-time",
-			Category:     "IT",
+			Category:     "it",
 			Requirements: "• Опыт работы в UI/UX дизайне от 2 лет\n• Владение Figma, Sketch, Adobe Creative Suite\n• Понимание принципов пользовательского опыта\n• Опыт создания wireframes и прототипов\n• Портфолио с примерами работ",
 			Benefits:     "• Творческая атмосфера\n• Возможность влиять на продукт\n• Современные инструменты\n• Команда дизайнеров",
 			EmployerID:   2,
@@ -258,9 +216,12 @@ func main() {
 			Description:  "Развиваем digital-направление компании. Ищем маркетолога для работы с социальными сетями и контент-маркетингом.",
 			Company:      "Marketing Agency",
 			Location:     "Москва",
-			Salary:       "80000-120000 руб.",
+			SalaryMin:    intPtr(80000),
+			SalaryMax:    intPtr(120000),
+			Currency:     "RUB",
+			SalaryPeriod: "month",
 			Type:         "full-time",
-			Category:     "Marketing",
+			Category:     "marketing",
 			Requirements: "• Опыт работы в digital-маркетинге от 1 года\n• Знание SMM, Google Analytics, Яндекс.Метрики\n• Опыт создания контент-планов\n• Навыки копирайтинга\n• Понимание SEO основ",
 			Benefits:     "• Работа с крупными клиентами\n• Возможность карьерного роста\n• Обучение новым инструментам\n• Гибкий график",
 			EmployerID:   2,
@@ -271,9 +232,12 @@ func main() {
 			Description:  "Автоматизируем процессы разработки и развертывания. Ищем DevOps инженера для работы с облачной инфраструктурой.",
 			Company:      "CloudTech",
 			Location:     "Москва",
-			Salary:       "180000-280000 руб.",
+			SalaryMin:    intPtr(180000),
+			SalaryMax:    intPtr(280000),
+			Currency:     "RUB",
+			SalaryPeriod: "month",
 			Type:         "full-time",
-			Category:     "IT",
+			Category:     "it",
 			Requirements: "• Опыт работы с AWS/Azure/GCP\n• Знание Docker, Kubernetes\n• Опыт работы с CI/CD (GitLab CI, Jenkins)\n• Знание Terraform, Ansible\n• Опыт мониторинга (Prometheus, Grafana)",
 			Benefits:     "• Работа с современными технологиями\n• Высокая зарплата\n• Возможность удаленной работы\n• Техническая команда",
 			EmployerID:   3,
@@ -284,9 +248,12 @@ func main() {
 			Description:  "Развиваем платформу для анализа данных. Ищем начинающего Python разработчика для работы с машинным обучением.",
 			Company:      "DataScience Corp",
 			Location:     "Санкт-Петербург",
-			Salary:       "100000-150000 руб.",
+			SalaryMin:    intPtr(100000),
+			SalaryMax:    intPtr(150000),
+			Currency:     "RUB",
+			SalaryPeriod: "month",
 			Type:         "full-time",
-			Category:     "IT",
+			Category:     "it",
 			Requirements: "• Знание Python, pandas, numpy\n• Базовые знания машинного обучения\n• Опыт работы с SQL\n• Желание изучать новые технологии\n• Математическое образование приветствуется",
 			Benefits:     "• Обучение и менторство\n• Работа с большими данными\n• Современный стек технологий\n• Возможность роста",
 			EmployerID:   3,
@@ -297,9 +264,12 @@ func main() {
 			Description:  "Управляем развитием мобильного приложения. Ищем продукт-менеджера для работы с командой разработки.",
 			Company:      "MobileApp Inc",
 			Location:     "Москва",
-			Salary:       "150000-220000 руб.",
+			SalaryMin:    intPtr(150000),
+			SalaryMax:    intPtr(220000),
+			Currency:     "RUB",
+			SalaryPeriod: "month",
 			Type:         "full-time",
-			Category:     "IT",
+			Category:     "it",
 			Requirements: "• Опыт работы в продуктовой разработке от 2 лет\n• Понимание Agile/Scrum методологий\n• Навыки аналитики и работы с метриками\n• Опыт работы с командой разработки\n• Техническое образование приветствуется",
 			Benefits:     "• Управление продуктом с миллионами пользователей\n• Работа с международной командой\n• Высокая зарплата\n• Возможность влиять на стратегию",
 			EmployerID:   2,
@@ -310,9 +280,12 @@ func main() {
 			Description:  "Создаем контент для IT-блога и социальных сетей. Ищем контент-менеджера с техническим бэкграундом.",
 			Company:      "TechBlog",
 			Location:     "Москва",
-			Salary:       "70000-100000 руб.",
+			SalaryMin:    intPtr(70000),
+			SalaryMax:    intPtr(100000),
+			Currency:     "RUB",
+			SalaryPeriod: "month",
 			Type:         "part-time",
-			Category:     "Marketing",
+			Category:     "marketing",
 			Requirements: "• Опыт создания технического контента\n• Знание IT-трендов и технологий\n• Навыки копирайтинга\n• Опыт работы с социальными сетями\n• Техническое образование приветствуется",
 			Benefits:     "• Гибкий график\n• Работа с интересными проектами\n• Возможность удаленной работы\n• Творческая свобода",
 			EmployerID:   2,
@@ -328,65 +301,65 @@ func main() {
 
 	// Create test applications
 	log.Println("Creating test applications...")
-	applications := []JobApplication{
+	applications := []models.JobApplication{
 		{
 			JobID:   1,
 			UserID:  4,
-			Status:  "pending",
+			Status:  models.ApplicationStatusApplied,
 			Message: "Здравствуйте! Меня очень заинтересовала вакансия Senior Go Developer. У меня есть опыт работы с Go и PostgreSQL, а также опыт работы с Docker. Готов к собеседованию!",
 		},
 		{
 			JobID:   1,
 			UserID:  6,
-			Status:  "pending",
+			Status:  models.ApplicationStatusApplied,
 			Message: "Добрый день! Хотя у меня нет прямого опыта с Go, я быстро обучаюсь и имею опыт с Python. Готов изучить Go для этой позиции.",
 		},
 		{
 			JobID:   2,
 			UserID:  4,
-			Status:  "accepted",
+			Status:  models.ApplicationStatusOffer,
 			Message: "Отличная вакансия! У меня есть опыт с React и TypeScript. Работал над похожими проектами в сфере образования.",
 		},
 		{
 			JobID:   2,
 			UserID:  5,
-			Status:  "pending",
+			Status:  models.ApplicationStatusApplied,
 			Message: "Привет! Я UI/UX дизайнер, но также изучаю React. Могу привнести дизайнерский взгляд в разработку интерфейсов.",
 		},
 		{
 			JobID:   3,
 			UserID:  5,
-			Status:  "accepted",
+			Status:  models.ApplicationStatusOffer,
 			Message: "Идеальная позиция для меня! У меня есть опыт создания интерфейсов для e-commerce проектов. Портфолио прилагаю.",
 		},
 		{
 			JobID:   4,
 			UserID:  6,
-			Status:  "pending",
+			Status:  models.ApplicationStatusApplied,
 			Message: "Здравствуйте! У меня есть опыт в digital-маркетинге и SMM. Работал с различными инструментами аналитики.",
 		},
 		{
 			JobID:   5,
 			UserID:  4,
-			Status:  "rejected",
+			Status:  models.ApplicationStatusRejected,
 			Message: "Интересная позиция, но у меня пока нет опыта с Kubernetes. Возможно, рассмотрите меня на более junior позицию?",
 		},
 		{
 			JobID:   6,
 			UserID:  4,
-			Status:  "pending",
+			Status:  models.ApplicationStatusApplied,
 			Message: "Отличная возможность для роста! У меня есть базовые знания Python и желание изучать машинное обучение.",
 		},
 		{
 			JobID:   7,
 			UserID:  6,
-			Status:  "pending",
+			Status:  models.ApplicationStatusApplied,
 			Message: "Хотя у меня нет прямого опыта в продуктовой разработке, я изучал Agile методологии и имею аналитический склад ума.",
 		},
 		{
 			JobID:   8,
 			UserID:  6,
-			Status:  "accepted",
+			Status:  models.ApplicationStatusOffer,
 			Message: "Идеально подходит! У меня есть опыт создания технического контента и работы с IT-аудиторией.",
 		},
 	}
@@ -395,6 +368,16 @@ func main() {
 		if err := db.Create(&application).Error; err != nil {
 			log.Fatal("Failed to create application:", err)
 		}
+
+		events := []models.ApplicationStatusEvent{{ApplicationID: application.ID, ToStatus: models.ApplicationStatusApplied, ChangedByID: application.UserID}}
+		if application.Status != models.ApplicationStatusApplied {
+			var employerID uint
+			db.Table("jobs").Select("employer_id").Where("id = ?", application.JobID).Scan(&employerID)
+			events = append(events, models.ApplicationStatusEvent{ApplicationID: application.ID, FromStatus: models.ApplicationStatusApplied, ToStatus: application.Status, ChangedByID: employerID})
+		}
+		if err := db.Create(&events).Error; err != nil {
+			log.Fatal("Failed to create application history:", err)
+		}
 	}
 
 	log.Println("Test data created successfully!")
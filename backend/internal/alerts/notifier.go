@@ -0,0 +1,89 @@
+// Package alerts finds newly posted jobs matching users' saved searches and
+// delivers them as digests.
+package alerts
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"job-search-backend/internal/mail"
+	"job-search-backend/internal/models"
+	"job-search-backend/internal/notifications"
+)
+
+// Digest is the set of new jobs found for one saved search.
+type Digest struct {
+	User   models.User
+	Search models.SavedSearch
+	Jobs   []models.Job // newest first, at most the worker's digest size
+	Total  int64        // number of new matches, may exceed len(Jobs)
+}
+
+// Notifier delivers digests through one channel.
+type Notifier interface {
+	Notify(ctx context.Context, digest Digest) error
+}
+
+// InAppNotifier stores digests as notifications shown in the app.
+type InAppNotifier struct {
+	Service *notifications.Service
+}
+
+func (n InAppNotifier) Notify(ctx context.Context, digest Digest) error {
+	link := fmt.Sprintf("/saved-searches/%d", digest.Search.ID)
+	if digest.Total == 1 && len(digest.Jobs) == 1 {
+		link = fmt.Sprintf("/jobs/%d", digest.Jobs[0].ID)
+	}
+
+	return n.Service.Send(ctx, models.Notification{
+		UserID: digest.User.ID,
+		Type:   models.NotificationSavedSearch,
+		Title:  fmt.Sprintf("Новые вакансии по запросу «%s»: %d", digest.Search.Name, digest.Total),
+		Body:   jobList(digest.Jobs, ""),
+		Link:   link,
+	})
+}
+
+// EmailNotifier emails digests to users who have email alerts enabled for the search.
+type EmailNotifier struct {
+	Mailer mail.Mailer
+	AppURL string // frontend base URL for job links
+}
+
+func (n EmailNotifier) Notify(ctx context.Context, digest Digest) error {
+	if !digest.Search.EmailAlerts {
+		return nil
+	}
+
+	appURL := strings.TrimRight(n.AppURL, "/")
+	var body strings.Builder
+	fmt.Fprintf(&body, "Здравствуйте!\n\nПо сохранённому поиску «%s» появились новые вакансии: %d.\n\n", digest.Search.Name, digest.Total)
+	body.WriteString(jobList(digest.Jobs, appURL))
+	if more := digest.Total - int64(len(digest.Jobs)); more > 0 {
+		fmt.Fprintf(&body, "\nИ ещё %d: %s/saved-searches/%d\n", more, appURL, digest.Search.ID)
+	}
+	body.WriteString("\nИзменить частоту уведомлений можно в настройках сохранённого поиска.\n")
+
+	return n.Mailer.Send(ctx, mail.Message{
+		To:      digest.User.Email,
+		Subject: fmt.Sprintf("Новые вакансии: %s", digest.Search.Name),
+		Body:    body.String(),
+	})
+}
+
+// jobList formats one line per job. Links are added when appURL is set.
+func jobList(jobs []models.Job, appURL string) string {
+	var b strings.Builder
+	for _, job := range jobs {
+		fmt.Fprintf(&b, "• %s — %s", job.Title, job.Company)
+		if job.Salary != "" {
+			fmt.Fprintf(&b, ", %s", job.Salary)
+		}
+		if appURL != "" {
+			fmt.Fprintf(&b, "\n  %s/jobs/%d", appURL, job.ID)
+		}
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
@@ -0,0 +1,113 @@
+package alerts
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"job-search-backend/internal/database"
+	"job-search-backend/internal/models"
+)
+
+const (
+	DefaultInterval   = 5 * time.Minute
+	DefaultDigestSize = 10
+)
+
+// Worker periodically checks saved searches whose alert period has elapsed
+// and sends the jobs published since the previous check to every notifier.
+type Worker struct {
+	Notifiers  []Notifier
+	Interval   time.Duration // how often due searches are looked for
+	DigestSize int           // maximum number of jobs listed in one digest
+}
+
+// Run checks for due searches every Interval until ctx is cancelled.
+func (w *Worker) Run(ctx context.Context) {
+	interval := w.Interval
+	if interval <= 0 {
+		interval = DefaultInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := w.RunOnce(ctx, time.Now()); err != nil {
+			log.Println("Saved search alerts failed:", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunOnce processes every saved search that is due at now.
+func (w *Worker) RunOnce(ctx context.Context, now time.Time) error {
+	for _, frequency := range []string{models.AlertFrequencyHourly, models.AlertFrequencyDaily, models.AlertFrequencyWeekly} {
+		var searches []models.SavedSearch
+		if err := database.DB.WithContext(ctx).
+			Where("frequency = ? AND alerts_checked_at <= ?", frequency, now.Add(-models.AlertInterval(frequency))).
+			Order("id ASC").
+			Find(&searches).Error; err != nil {
+			return err
+		}
+
+		for _, search := range searches {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			if err := w.process(ctx, search, now); err != nil {
+				log.Printf("Saved search %d alerts failed: %v", search.ID, err)
+			}
+		}
+	}
+	return nil
+}
+
+// process sends the digest for one search and moves its checkpoint to now.
+// Delivery errors of individual notifiers are logged and do not hold the
+// checkpoint back, so a failing channel cannot cause duplicates on the others.
+func (w *Worker) process(ctx context.Context, search models.SavedSearch, now time.Time) error {
+	db := database.DB.WithContext(ctx)
+	// Scheduled jobs are matched once their publish_at has passed, even if the
+	// scheduler has not activated them yet; the checkpoint would skip them later.
+	// A publish_at before created_at does not backdate the job past a checkpoint.
+	query := search.Filter.Apply(db.Model(&models.Job{}).
+		Where("jobs.is_active = ? OR jobs.publish_at IS NOT NULL", true).
+		Where("jobs.closed_at IS NULL AND (jobs.expires_at IS NULL OR jobs.expires_at > ?)", now).
+		Where("GREATEST(jobs.publish_at, jobs.created_at) > ? AND GREATEST(jobs.publish_at, jobs.created_at) <= ?", search.AlertsCheckedAt, now))
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return err
+	}
+
+	if total > 0 {
+		digestSize := w.DigestSize
+		if digestSize <= 0 {
+			digestSize = DefaultDigestSize
+		}
+
+		var jobs []models.Job
+		if err := query.Order("GREATEST(jobs.publish_at, jobs.created_at) DESC").Limit(digestSize).Find(&jobs).Error; err != nil {
+			return err
+		}
+		var user models.User
+		if err := db.First(&user, search.UserID).Error; err != nil {
+			return err
+		}
+
+		digest := Digest{User: user, Search: search, Jobs: jobs, Total: total}
+		for _, notifier := range w.Notifiers {
+			if err := notifier.Notify(ctx, digest); err != nil {
+				log.Printf("Failed to deliver saved search %d digest: %v", search.ID, err)
+			}
+		}
+	}
+
+	return db.Model(&models.SavedSearch{}).Where("id = ?", search.ID).Update("alerts_checked_at", now).Error
+}
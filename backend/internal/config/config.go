@@ -0,0 +1,189 @@
+// Package config loads the application configuration.
+//
+// Every setting has a default, may be set in an optional YAML or TOML file
+// (named by CONFIG_FILE) and may be overridden by an environment variable,
+// which can also come from a .env file in the working directory. Empty
+// variables count as unset. Settings are listed with their keys and
+// variables by `main config print`.
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+type Config struct {
+	Server   Server   `config:"server"`
+	Database Database `config:"database"`
+	Auth     Auth     `config:"auth"`
+	Storage  Storage  `config:"storage"`
+	Mail     Mail     `config:"mail"`
+	Workers  Workers  `config:"workers"`
+}
+
+type Server struct {
+	Port int `config:"port" env:"PORT" default:"8080"`
+	// Mode is the gin mode: debug, release or test
+	Mode string `config:"mode" env:"GIN_MODE" default:"debug"`
+	// FrontendURL is the absolute address of the web app, used for links in emails
+	FrontendURL string `config:"frontend_url" env:"FRONTEND_URL" default:"http://localhost:3000"`
+
+	ReadHeaderTimeout time.Duration `config:"read_header_timeout" env:"SERVER_READ_HEADER_TIMEOUT" default:"10s"`
+	ReadTimeout       time.Duration `config:"read_timeout" env:"SERVER_READ_TIMEOUT" default:"1m"`
+	// WriteTimeout is off by default, as it would cut notification streams
+	WriteTimeout time.Duration `config:"write_timeout" env:"SERVER_WRITE_TIMEOUT" default:"0s"`
+	IdleTimeout  time.Duration `config:"idle_timeout" env:"SERVER_IDLE_TIMEOUT" default:"2m"`
+	// ShutdownDelay is how long /readyz fails before the server stops
+	// accepting connections, so load balancers can take it out of rotation;
+	// 0s stops at once
+	ShutdownDelay time.Duration `config:"shutdown_delay" env:"SHUTDOWN_DELAY" default:"5s"`
+	// ShutdownTimeout is how long in-flight requests may take to finish
+	ShutdownTimeout time.Duration `config:"shutdown_timeout" env:"SHUTDOWN_TIMEOUT" default:"30s"`
+}
+
+type Database struct {
+	Host     string `config:"host" env:"DB_HOST" default:"localhost"`
+	Port     int    `config:"port" env:"DB_PORT" default:"5432"`
+	User     string `config:"user" env:"DB_USER" default:"postgres"`
+	Password string `config:"password" env:"DB_PASSWORD" secret:"true"`
+	Name     string `config:"name" env:"DB_NAME" default:"jobsearch"`
+	SSLMode  string `config:"sslmode" env:"DB_SSLMODE" default:"disable"`
+
+	// Connection pool
+	MaxOpenConns    int           `config:"max_open_conns" env:"DB_MAX_OPEN_CONNS" default:"25"`
+	MaxIdleConns    int           `config:"max_idle_conns" env:"DB_MAX_IDLE_CONNS" default:"5"`
+	ConnMaxLifetime time.Duration `config:"conn_max_lifetime" env:"DB_CONN_MAX_LIFETIME" default:"30m"`
+	ConnMaxIdleTime time.Duration `config:"conn_max_idle_time" env:"DB_CONN_MAX_IDLE_TIME" default:"5m"`
+}
+
+// DSN returns the PostgreSQL connection string.
+func (d Database) DSN() string {
+	return fmt.Sprintf("host=%s user=%s password=%s dbname=%s port=%d sslmode=%s",
+		quoteDSN(d.Host), quoteDSN(d.User), quoteDSN(d.Password), quoteDSN(d.Name), d.Port, quoteDSN(d.SSLMode))
+}
+
+// quoteDSN quotes a keyword/value connection string value when needed.
+func quoteDSN(value string) string {
+	if value != "" && !strings.ContainsAny(value, ` '\`) {
+		return value
+	}
+	return "'" + strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(value) + "'"
+}
+
+type Auth struct {
+	// JWTSecret signs access tokens and email action tokens
+	JWTSecret string `config:"jwt_secret" env:"JWT_SECRET" secret:"true"`
+	// EmailVerificationRequiredFor lists what unverified accounts may not do: login, apply
+	EmailVerificationRequiredFor []string `config:"email_verification_required_for" env:"EMAIL_VERIFICATION_REQUIRED_FOR"`
+}
+
+type Storage struct {
+	// Driver is local or s3
+	Driver   string `config:"driver" env:"STORAGE_DRIVER" default:"local"`
+	LocalDir string `config:"local_dir" env:"STORAGE_LOCAL_DIR" default:"uploads"`
+
+	S3Endpoint        string `config:"s3_endpoint" env:"S3_ENDPOINT"`
+	S3Region          string `config:"s3_region" env:"S3_REGION" default:"us-east-1"`
+	S3Bucket          string `config:"s3_bucket" env:"S3_BUCKET"`
+	S3AccessKeyID     string `config:"s3_access_key_id" env:"S3_ACCESS_KEY_ID"`
+	S3SecretAccessKey string `config:"s3_secret_access_key" env:"S3_SECRET_ACCESS_KEY" secret:"true"`
+
+	// MaxResumeSize is the upload limit for resumes, in bytes
+	MaxResumeSize int64 `config:"max_resume_size" env:"MAX_RESUME_SIZE" default:"5242880"`
+}
+
+type Mail struct {
+	// Driver is file (writes messages to Dir), memory or smtp
+	Driver string `config:"driver" env:"MAIL_DRIVER" default:"file"`
+	Dir    string `config:"dir" env:"MAIL_DIR" default:"mail"`
+	From   string `config:"from" env:"MAIL_FROM" default:"no-reply@jobsearch.local"`
+
+	SMTPHost     string `config:"smtp_host" env:"SMTP_HOST"`
+	SMTPPort     int    `config:"smtp_port" env:"SMTP_PORT" default:"587"`
+	SMTPUsername string `config:"smtp_username" env:"SMTP_USERNAME"`
+	SMTPPassword string `config:"smtp_password" env:"SMTP_PASSWORD" secret:"true"`
+}
+
+type Workers struct {
+	// AlertsInterval is how often saved searches are checked for new matching jobs
+	AlertsInterval time.Duration `config:"alerts_interval" env:"ALERTS_INTERVAL" default:"5m"`
+	// JobSchedulerInterval is how often scheduled jobs are published and expired ones closed
+	JobSchedulerInterval time.Duration `config:"job_scheduler_interval" env:"JOB_SCHEDULER_INTERVAL" default:"1m"`
+}
+
+// Validate checks the settings, reporting every problem at once.
+func (c *Config) Validate() error {
+	var problems []string
+	check := func(ok bool, format string, args ...interface{}) {
+		if !ok {
+			problems = append(problems, fmt.Sprintf(format, args...))
+		}
+	}
+
+	check(c.Server.Port > 0 && c.Server.Port < 65536, "PORT must be between 1 and 65535")
+	check(oneOf(c.Server.Mode, "debug", "release", "test"), "GIN_MODE must be debug, release or test")
+	check(c.Server.ReadHeaderTimeout >= 0 && c.Server.ReadTimeout >= 0 && c.Server.WriteTimeout >= 0 && c.Server.IdleTimeout >= 0,
+		"SERVER_READ_HEADER_TIMEOUT, SERVER_READ_TIMEOUT, SERVER_WRITE_TIMEOUT and SERVER_IDLE_TIMEOUT must not be negative")
+	check(c.Server.ShutdownDelay >= 0, "SHUTDOWN_DELAY must not be negative")
+	check(c.Server.ShutdownTimeout > 0, "SHUTDOWN_TIMEOUT must be positive")
+	check(absoluteHTTPURL(c.Server.FrontendURL), "FRONTEND_URL must be an absolute http or https URL")
+
+	check(c.Database.Host != "", "DB_HOST is required")
+	check(c.Database.Port > 0 && c.Database.Port < 65536, "DB_PORT must be between 1 and 65535")
+	check(c.Database.User != "", "DB_USER is required")
+	check(c.Database.Name != "", "DB_NAME is required")
+	check(oneOf(c.Database.SSLMode, "disable", "allow", "prefer", "require", "verify-ca", "verify-full"),
+		"DB_SSLMODE must be disable, allow, prefer, require, verify-ca or verify-full")
+	check(c.Database.MaxOpenConns >= 0 && c.Database.MaxIdleConns >= 0, "DB_MAX_OPEN_CONNS and DB_MAX_IDLE_CONNS must not be negative")
+
+	check(c.Auth.JWTSecret != "", "JWT_SECRET is required")
+	for _, action := range c.Auth.EmailVerificationRequiredFor {
+		check(oneOf(action, "login", "apply"), "EMAIL_VERIFICATION_REQUIRED_FOR: unknown action %q, expected login or apply", action)
+	}
+
+	switch c.Storage.Driver {
+	case "local":
+		check(c.Storage.LocalDir != "", "STORAGE_LOCAL_DIR is required by the local storage driver")
+	case "s3":
+		check(c.Storage.S3Endpoint != "" && c.Storage.S3Bucket != "", "S3_ENDPOINT and S3_BUCKET are required by the s3 storage driver")
+		check(c.Storage.S3AccessKeyID != "" && c.Storage.S3SecretAccessKey != "", "S3_ACCESS_KEY_ID and S3_SECRET_ACCESS_KEY are required by the s3 storage driver")
+	default:
+		check(false, "STORAGE_DRIVER must be local or s3")
+	}
+	check(c.Storage.MaxResumeSize > 0, "MAX_RESUME_SIZE must be positive")
+
+	switch c.Mail.Driver {
+	case "file":
+		check(c.Mail.Dir != "", "MAIL_DIR is required by the file mail driver")
+	case "memory":
+	case "smtp":
+		check(c.Mail.SMTPHost != "", "SMTP_HOST is required by the smtp mail driver")
+	default:
+		check(false, "MAIL_DRIVER must be file, memory or smtp")
+	}
+
+	check(c.Workers.AlertsInterval > 0, "ALERTS_INTERVAL must be positive")
+	check(c.Workers.JobSchedulerInterval > 0, "JOB_SCHEDULER_INTERVAL must be positive")
+
+	if len(problems) > 0 {
+		return fmt.Errorf("invalid configuration:\n  %s", strings.Join(problems, "\n  "))
+	}
+	return nil
+}
+
+func oneOf(value string, allowed ...string) bool {
+	for _, a := range allowed {
+		if value == a {
+			return true
+		}
+	}
+	return false
+}
+
+// absoluteHTTPURL reports whether value is an http or https URL with a host.
+func absoluteHTTPURL(value string) bool {
+	u, err := url.Parse(value)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
@@ -0,0 +1,133 @@
+package config
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// writeFile writes a config file to a temporary directory and points
+// CONFIG_FILE at it.
+func writeFile(t *testing.T, name, content string) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("CONFIG_FILE", path)
+}
+
+func TestPrecedence(t *testing.T) {
+	writeFile(t, "config.yaml", `
+server:
+  port: 9000
+database:
+  host: db.internal
+  conn_max_lifetime: 1h
+auth:
+  email_verification_required_for: [login, apply]
+`)
+	t.Setenv("DB_HOST", "db.override")
+	t.Setenv("PORT", "")
+
+	cfg, err := Read()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Server.Port != 9000 {
+		t.Errorf("port: got %d, want the file value 9000 as PORT is empty", cfg.Server.Port)
+	}
+	if cfg.Database.Host != "db.override" {
+		t.Errorf("host: got %q, want the environment value", cfg.Database.Host)
+	}
+	if cfg.Database.ConnMaxLifetime != time.Hour {
+		t.Errorf("conn_max_lifetime: got %s, want 1h", cfg.Database.ConnMaxLifetime)
+	}
+	if cfg.Database.Name != "jobsearch" {
+		t.Errorf("name: got %q, want the default", cfg.Database.Name)
+	}
+	if cfg.Server.ShutdownDelay != 5*time.Second {
+		t.Errorf("shutdown_delay: got %s, want the 5s default", cfg.Server.ShutdownDelay)
+	}
+	if got := strings.Join(cfg.Auth.EmailVerificationRequiredFor, ","); got != "login,apply" {
+		t.Errorf("email_verification_required_for: got %q", got)
+	}
+}
+
+func TestTOMLFile(t *testing.T) {
+	writeFile(t, "config.toml", "[workers]\nalerts_interval = \"10m\"\n")
+
+	cfg, err := Read()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Workers.AlertsInterval != 10*time.Minute {
+		t.Errorf("alerts_interval: got %s, want 10m", cfg.Workers.AlertsInterval)
+	}
+}
+
+func TestUnknownSetting(t *testing.T) {
+	writeFile(t, "config.yaml", "database:\n  hots: db.internal\n")
+
+	if _, err := Read(); err == nil || !strings.Contains(err.Error(), "database.hots") {
+		t.Fatalf("got %v, want an unknown setting error", err)
+	}
+}
+
+func TestInvalidValue(t *testing.T) {
+	t.Setenv("DB_PORT", "five")
+
+	if _, err := Read(); err == nil || !strings.Contains(err.Error(), "DB_PORT") {
+		t.Fatalf("got %v, want an error naming DB_PORT", err)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	t.Setenv("JWT_SECRET", "")
+	t.Setenv("STORAGE_DRIVER", "s3")
+	t.Setenv("FRONTEND_URL", "/app")
+
+	_, err := Load()
+	if err == nil {
+		t.Fatal("expected a validation error")
+	}
+	for _, want := range []string{"JWT_SECRET is required", "S3_ENDPOINT and S3_BUCKET", "FRONTEND_URL must be an absolute"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q does not mention %q", err, want)
+		}
+	}
+
+	t.Setenv("JWT_SECRET", "secret")
+	t.Setenv("STORAGE_DRIVER", "local")
+	t.Setenv("FRONTEND_URL", "https://jobs.example.com")
+	if _, err := Load(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestPrintRedactsSecrets(t *testing.T) {
+	t.Setenv("JWT_SECRET", "super-secret")
+	t.Setenv("DB_PASSWORD", "")
+
+	cfg, err := Read()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var out bytes.Buffer
+	if err := Print(&out, cfg); err != nil {
+		t.Fatal(err)
+	}
+
+	printed := out.String()
+	if strings.Contains(printed, "super-secret") {
+		t.Fatalf("secret printed:\n%s", printed)
+	}
+	for _, want := range []string{"jwt_secret: '[redacted]' # JWT_SECRET", "password: \"\" # DB_PASSWORD", "port: 8080 # PORT"} {
+		if !strings.Contains(printed, want) {
+			t.Errorf("missing %q in:\n%s", want, printed)
+		}
+	}
+}
@@ -0,0 +1,194 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/joho/godotenv"
+	"github.com/pelletier/go-toml/v2"
+	"gopkg.in/yaml.v3"
+)
+
+// Load reads the configuration and validates it.
+func Load() (*Config, error) {
+	cfg, err := Read()
+	if err != nil {
+		return nil, err
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// Read reads the configuration from the defaults, the file named by
+// CONFIG_FILE, .env and the environment, in increasing priority, without
+// validating it.
+func Read() (*Config, error) {
+	// Variables already set in the environment take precedence over .env
+	if err := godotenv.Load(); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("reading .env: %w", err)
+	}
+
+	cfg := &Config{}
+	settings := settingsOf(cfg)
+	for _, s := range settings {
+		if err := s.set(s.def); err != nil {
+			return nil, fmt.Errorf("default of %s: %w", s.key, err)
+		}
+	}
+
+	if path := os.Getenv("CONFIG_FILE"); path != "" {
+		if err := readFile(path, settings); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, s := range settings {
+		if value := os.Getenv(s.env); value != "" {
+			if err := s.set(value); err != nil {
+				return nil, fmt.Errorf("%s: %w", s.env, err)
+			}
+		}
+	}
+	return cfg, nil
+}
+
+// setting is one configurable field of a Config.
+type setting struct {
+	section string
+	key     string // section.name, as in the config file
+	env     string
+	def     string
+	secret  bool
+	value   reflect.Value
+}
+
+// settingsOf lists the settings of cfg in declaration order.
+func settingsOf(cfg *Config) []setting {
+	var settings []setting
+	root := reflect.ValueOf(cfg).Elem()
+	for i := 0; i < root.NumField(); i++ {
+		section := root.Type().Field(i).Tag.Get("config")
+		fields := root.Field(i)
+		for j := 0; j < fields.NumField(); j++ {
+			field := fields.Type().Field(j)
+			settings = append(settings, setting{
+				section: section,
+				key:     section + "." + field.Tag.Get("config"),
+				env:     field.Tag.Get("env"),
+				def:     field.Tag.Get("default"),
+				secret:  field.Tag.Get("secret") == "true",
+				value:   fields.Field(j),
+			})
+		}
+	}
+	return settings
+}
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// set parses value into the setting. Lists are comma-separated.
+func (s setting) set(value string) error {
+	value = strings.TrimSpace(value)
+	switch {
+	case s.value.Type() == durationType:
+		d := time.Duration(0)
+		if value != "" {
+			var err error
+			if d, err = time.ParseDuration(value); err != nil {
+				return err
+			}
+		}
+		s.value.SetInt(int64(d))
+	case s.value.Kind() == reflect.String:
+		s.value.SetString(value)
+	case s.value.Kind() == reflect.Int || s.value.Kind() == reflect.Int64:
+		n := int64(0)
+		if value != "" {
+			var err error
+			if n, err = strconv.ParseInt(value, 10, 64); err != nil {
+				return fmt.Errorf("%q is not an integer", value)
+			}
+		}
+		s.value.SetInt(n)
+	case s.value.Kind() == reflect.Slice:
+		var list []string
+		for _, item := range strings.Split(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				list = append(list, item)
+			}
+		}
+		s.value.Set(reflect.ValueOf(list))
+	default:
+		return fmt.Errorf("unsupported type %s", s.value.Type())
+	}
+	return nil
+}
+
+// readFile applies the settings of a YAML or TOML file, chosen by extension.
+// Unknown sections and keys are rejected to catch typos.
+func readFile(path string, settings []setting) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	var sections map[string]interface{}
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &sections)
+	case ".toml":
+		err = toml.Unmarshal(data, &sections)
+	default:
+		return fmt.Errorf("%s: unsupported config file type %q, expected .yaml, .yml or .toml", path, ext)
+	}
+	if err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+
+	byKey := map[string]setting{}
+	for _, s := range settings {
+		byKey[s.key] = s
+	}
+	for section, content := range sections {
+		values, ok := content.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("%s: %s must be a table of settings", path, section)
+		}
+		for name, raw := range values {
+			s, ok := byKey[section+"."+name]
+			if !ok {
+				return fmt.Errorf("%s: unknown setting %s.%s", path, section, name)
+			}
+			if err := s.set(fileValue(raw)); err != nil {
+				return fmt.Errorf("%s: %s: %w", path, s.key, err)
+			}
+		}
+	}
+	return nil
+}
+
+// fileValue formats a decoded file value the way it would be written in an
+// environment variable.
+func fileValue(raw interface{}) string {
+	switch v := raw.(type) {
+	case nil:
+		return ""
+	case []interface{}:
+		items := make([]string, len(v))
+		for i, item := range v {
+			items[i] = fmt.Sprint(item)
+		}
+		return strings.Join(items, ",")
+	default:
+		return fmt.Sprint(v)
+	}
+}
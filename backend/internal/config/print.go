@@ -0,0 +1,52 @@
+package config
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// redacted replaces the value of secrets that are set.
+const redacted = "[redacted]"
+
+// Print writes cfg as a YAML config file, each setting annotated with its
+// environment variable. Secrets are redacted.
+func Print(w io.Writer, cfg *Config) error {
+	root := &yaml.Node{Kind: yaml.MappingNode}
+	var section *yaml.Node
+	for _, s := range settingsOf(cfg) {
+		if section == nil || root.Content[len(root.Content)-2].Value != s.section {
+			section = &yaml.Node{Kind: yaml.MappingNode}
+			root.Content = append(root.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: s.section}, section)
+		}
+
+		value := &yaml.Node{}
+		switch v := s.value.Interface().(type) {
+		case time.Duration:
+			value.SetString(v.String())
+		case []string:
+			value.SetString(strings.Join(v, ","))
+		default:
+			if err := value.Encode(v); err != nil {
+				return err
+			}
+		}
+		if s.secret && value.Value != "" {
+			value.SetString(redacted)
+		}
+		value.LineComment = s.env
+
+		name := strings.TrimPrefix(s.key, s.section+".")
+		section.Content = append(section.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: name}, value)
+	}
+
+	encoder := yaml.NewEncoder(w)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(root); err != nil {
+		return fmt.Errorf("printing config: %w", err)
+	}
+	return encoder.Close()
+}
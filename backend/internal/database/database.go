@@ -1,10 +1,11 @@
 package database
 
 import (
-	"fmt"
+	"context"
 	"log"
-	"os"
 
+	"job-search-backend/internal/config"
+	"job-search-backend/internal/migrate"
 	"job-search-backend/internal/models"
 
 	"gorm.io/driver/postgres"
@@ -14,18 +15,10 @@ import (
 
 var DB *gorm.DB
 
-func Connect() {
+func Connect(cfg config.Database) {
 	var err error
-	
-	dsn := fmt.Sprintf("host=%s user=%s password=%s dbname=%s port=%s sslmode=disable",
-		os.Getenv("DB_HOST"),
-		os.Getenv("DB_USER"),
-		os.Getenv("DB_PASSWORD"),
-		os.Getenv("DB_NAME"),
-		os.Getenv("DB_PORT"),
-	)
-
-	DB, err = gorm.Open(postgres.Open(dsn), &gorm.Config{
+
+	DB, err = gorm.Open(postgres.Open(cfg.DSN()), &gorm.Config{
 		Logger: logger.Default.LogMode(logger.Info),
 	})
 
@@ -33,22 +26,68 @@ func Connect() {
 		log.Fatal("Failed to connect to database:", err)
 	}
 
+	sqlDB, err := DB.DB()
+	if err != nil {
+		log.Fatal("Failed to configure the connection pool:", err)
+	}
+	sqlDB.SetMaxOpenConns(cfg.MaxOpenConns)
+	sqlDB.SetMaxIdleConns(cfg.MaxIdleConns)
+	sqlDB.SetConnMaxLifetime(cfg.ConnMaxLifetime)
+	sqlDB.SetConnMaxIdleTime(cfg.ConnMaxIdleTime)
+
 	log.Println("Database connected successfully")
 }
 
+// Close closes the connection pool.
+func Close() error {
+	sqlDB, err := DB.DB()
+	if err != nil {
+		return err
+	}
+	return sqlDB.Close()
+}
+
+// legacyBaseline is the migration version that databases created before
+// versioned migrations are upgraded to and recorded at.
+const legacyBaseline = 1
+
+// Migrate applies the pending schema migrations.
 func Migrate() {
-	err := DB.AutoMigrate(
-		&models.User{},
-		&models.UserProfile{},
-		&models.Job{},
-		&models.JobApplication{},
-	)
+	ctx := context.Background()
+	migrator, err := migrate.New(DB)
+	if err != nil {
+		log.Fatal("Failed to load migrations:", err)
+	}
+
+	if DB.Migrator().HasTable(&models.User{}) && !DB.Migrator().HasTable("schema_migrations") {
+		// The upgrade and its baseline commit together under the migrators'
+		// lock, so that replicas starting at once upgrade only once
+		err := migrator.Locked(ctx, func(tx *gorm.DB) error {
+			if tx.Migrator().HasTable("schema_migrations") {
+				return nil
+			}
+			log.Println("Upgrading a database created before versioned migrations")
+			if err := upgradeLegacySchema(tx); err != nil {
+				return err
+			}
+			baseline, err := migrate.New(tx)
+			if err != nil {
+				return err
+			}
+			return baseline.Baseline(ctx, legacyBaseline)
+		})
+		if err != nil {
+			log.Fatal("Failed to upgrade the legacy database:", err)
+		}
+	}
 
+	applied, err := migrator.Up(ctx)
+	for _, migration := range applied {
+		log.Printf("Applied migration %s", migration)
+	}
 	if err != nil {
 		log.Fatal("Failed to migrate database:", err)
 	}
 
 	log.Println("Database migration completed")
 }
-
-
@@ -0,0 +1,186 @@
+package database
+
+import (
+	"fmt"
+	"log"
+
+	"job-search-backend/internal/database/schemav1"
+	"job-search-backend/internal/models"
+	"job-search-backend/internal/utils"
+
+	"gorm.io/gorm"
+)
+
+// upgradeLegacySchema brings a database managed by AutoMigrate, at any
+// earlier state, up to the legacyBaseline schema. It works on the frozen
+// schemav1 models, never the live ones; new schema changes go into
+// migrations, not here.
+func upgradeLegacySchema(db *gorm.DB) error {
+	// Accounts created before email verification existed are treated as verified
+	backfillEmailVerification := db.Migrator().HasTable(&schemav1.User{}) &&
+		!db.Migrator().HasColumn(&schemav1.User{}, "email_verified_at")
+	// Free-text locations predating structured ones are geocoded once
+	backfillLocations := db.Migrator().HasTable(&schemav1.Job{}) &&
+		!db.Migrator().HasColumn(&schemav1.Job{}, "work_mode")
+
+	if err := db.AutoMigrate(schemav1.Models()...); err != nil {
+		return err
+	}
+
+	if backfillEmailVerification {
+		if err := db.Exec("UPDATE users SET email_verified_at = created_at WHERE email_verified_at IS NULL").Error; err != nil {
+			return fmt.Errorf("backfilling email verification: %w", err)
+		}
+	}
+
+	if err := migrateLegacySalary(db); err != nil {
+		return fmt.Errorf("migrating salaries: %w", err)
+	}
+
+	if err := migrateJobSearch(db); err != nil {
+		return fmt.Errorf("creating the job search index: %w", err)
+	}
+
+	if err := migrateApplicationStatuses(db); err != nil {
+		return fmt.Errorf("migrating application statuses: %w", err)
+	}
+
+	if err := migrateJobTaxonomy(db); err != nil {
+		return fmt.Errorf("migrating job categories and types: %w", err)
+	}
+
+	if backfillLocations {
+		if err := migrateLocations(db); err != nil {
+			return fmt.Errorf("geocoding locations: %w", err)
+		}
+	}
+	if err := db.Exec("CREATE INDEX IF NOT EXISTS idx_jobs_coordinates ON jobs (latitude, longitude)").Error; err != nil {
+		return fmt.Errorf("creating the job coordinates index: %w", err)
+	}
+	return nil
+}
+
+// migrateLegacySalary converts the old free-text jobs.salary column into
+// salary_min/salary_max/currency/salary_period and then drops it.
+func migrateLegacySalary(db *gorm.DB) error {
+	if !db.Migrator().HasColumn("jobs", "salary") {
+		return nil
+	}
+
+	type legacyJob struct {
+		ID     uint
+		Salary string
+	}
+	var rows []legacyJob
+	if err := db.Table("jobs").Select("id, salary").Where("salary IS NOT NULL AND salary <> ''").Scan(&rows).Error; err != nil {
+		return err
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		for _, row := range rows {
+			parsed, ok := utils.ParseSalary(row.Salary)
+			if !ok {
+				log.Printf("Could not parse salary %q of job %d, leaving it empty", row.Salary, row.ID)
+				continue
+			}
+			if err := tx.Table("jobs").Where("id = ?", row.ID).Updates(map[string]interface{}{
+				"salary_min":    parsed.Min,
+				"salary_max":    parsed.Max,
+				"currency":      parsed.Currency,
+				"salary_period": parsed.Period,
+			}).Error; err != nil {
+				return err
+			}
+		}
+		return tx.Migrator().DropColumn("jobs", "salary")
+	})
+}
+
+// migrateLocations fills the structured location of existing jobs and
+// profiles from their free-text location using the offline gazetteer, and
+// marks jobs whose location mentions remote or hybrid work accordingly.
+func migrateLocations(db *gorm.DB) error {
+	type row struct {
+		ID       uint
+		Location string
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		for _, table := range []string{"jobs", "user_profiles"} {
+			var rows []row
+			if err := tx.Table(table).Select("id, location").Where("location IS NOT NULL AND location <> ''").Scan(&rows).Error; err != nil {
+				return err
+			}
+
+			geocoded := 0
+			for _, r := range rows {
+				updates := map[string]interface{}{}
+				var place models.Place
+				if place.Geocode(r.Location) {
+					updates["city"] = place.City
+					updates["country"] = place.Country
+					updates["latitude"] = place.Latitude
+					updates["longitude"] = place.Longitude
+					geocoded++
+				}
+				if mode := models.GuessWorkMode(r.Location); mode != "" && table == "jobs" {
+					updates["work_mode"] = mode
+				}
+				if len(updates) == 0 {
+					continue
+				}
+				if err := tx.Table(table).Where("id = ?", r.ID).Updates(updates).Error; err != nil {
+					return err
+				}
+			}
+			log.Printf("Geocoded %d of %d %s locations", geocoded, len(rows), table)
+		}
+		return nil
+	})
+}
+
+// migrateJobSearch adds the generated tsvector column used by full-text job
+// search and its GIN index. Titles weigh the most, then company, requirements
+// and description. Every text field is indexed with both the Russian and the
+// English stemmer because postings freely mix the two languages.
+func migrateJobSearch(db *gorm.DB) error {
+	if !db.Migrator().HasColumn("jobs", "search_vector") {
+		err := db.Exec(`ALTER TABLE jobs ADD COLUMN search_vector tsvector GENERATED ALWAYS AS (
+			setweight(to_tsvector('russian', coalesce(title, '')), 'A') ||
+			setweight(to_tsvector('english', coalesce(title, '')), 'A') ||
+			setweight(to_tsvector('simple', coalesce(company, '')), 'B') ||
+			setweight(to_tsvector('russian', coalesce(requirements, '')), 'C') ||
+			setweight(to_tsvector('english', coalesce(requirements, '')), 'C') ||
+			setweight(to_tsvector('russian', coalesce(description, '')), 'D') ||
+			setweight(to_tsvector('english', coalesce(description, '')), 'D')
+		) STORED`).Error
+		if err != nil {
+			return err
+		}
+	}
+	return db.Exec("CREATE INDEX IF NOT EXISTS idx_jobs_search_vector ON jobs USING GIN (search_vector)").Error
+}
+
+// migrateApplicationStatuses maps the statuses of the old three-step workflow
+// onto the new pipeline and backfills the timeline of applications that do
+// not have one yet.
+func migrateApplicationStatuses(db *gorm.DB) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("UPDATE job_applications SET status = 'applied' WHERE status = 'pending' OR status = ''").Error; err != nil {
+			return err
+		}
+		if err := tx.Exec("UPDATE job_applications SET status = 'offer' WHERE status = 'accepted'").Error; err != nil {
+			return err
+		}
+		// Applications decided before the timeline existed get the submission
+		// and their current status attributed to the job's employer.
+		return tx.Exec(`INSERT INTO application_status_events (application_id, from_status, to_status, changed_by_id, note, created_at)
+			SELECT a.id, '', 'applied', a.user_id, '', a.created_at
+			FROM job_applications a
+			WHERE NOT EXISTS (SELECT 1 FROM application_status_events e WHERE e.application_id = a.id)
+			UNION ALL
+			SELECT a.id, 'applied', a.status, j.employer_id, 'Migrated from the previous workflow', a.updated_at
+			FROM job_applications a JOIN jobs j ON j.id = a.job_id
+			WHERE a.status <> 'applied' AND NOT EXISTS (SELECT 1 FROM application_status_events e WHERE e.application_id = a.id)`).Error
+	})
+}
@@ -0,0 +1,305 @@
+// Package schemav1 is a frozen copy of the models at migration 0001, the
+// schema that databases created by AutoMigrate are upgraded to before
+// versioned migrations take over. It must never change: later schema changes
+// go into migrations/, and AutoMigrating the live models here would apply
+// them twice.
+package schemav1
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type User struct {
+	ID              uint   `gorm:"primaryKey"`
+	Email           string `gorm:"unique;not null"`
+	Password        string `gorm:"not null"`
+	Name            string `gorm:"not null"`
+	Role            string `gorm:"default:'job_seeker'"`
+	EmailVerifiedAt *time.Time
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+	DeletedAt       gorm.DeletedAt `gorm:"index"`
+
+	UserProfile *UserProfile `gorm:"foreignKey:UserID"`
+}
+
+type UserProfile struct {
+	ID         uint  `gorm:"primaryKey"`
+	UserID     uint  `gorm:"uniqueIndex;not null"`
+	User       *User `gorm:"foreignKey:UserID"`
+	Phone      string
+	Location   string
+	WorkMode   string
+	Experience string
+	Skills     string
+	Education  string
+	Resume     string
+
+	Place
+
+	ResumeKey         string
+	ResumeFileName    string
+	ResumeContentType string
+	ResumeSize        int64
+	ResumeUploadedAt  *time.Time
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	DeletedAt gorm.DeletedAt `gorm:"index"`
+}
+
+type RoleChange struct {
+	ID          uint `gorm:"primaryKey"`
+	UserID      uint `gorm:"index;not null"`
+	User        User `gorm:"foreignKey:UserID"`
+	ChangedByID uint `gorm:"not null"`
+	ChangedBy   User `gorm:"foreignKey:ChangedByID"`
+	OldRole     string
+	NewRole     string
+	Reason      string `gorm:"type:text"`
+	CreatedAt   time.Time
+}
+
+type Place struct {
+	City      string
+	Country   string `gorm:"size:2;index"`
+	Latitude  *float64
+	Longitude *float64
+}
+
+type Company struct {
+	ID          uint   `gorm:"primaryKey"`
+	Name        string `gorm:"not null"`
+	Slug        string `gorm:"uniqueIndex;not null"`
+	Description string `gorm:"type:text"`
+	Website     string
+	LogoURL     string
+	Location    string
+	Size        string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	DeletedAt   gorm.DeletedAt `gorm:"index"`
+
+	Members []CompanyMember `gorm:"foreignKey:CompanyID"`
+}
+
+type CompanyMember struct {
+	ID        uint     `gorm:"primaryKey"`
+	CompanyID uint     `gorm:"uniqueIndex:idx_company_members_company_user;not null"`
+	Company   *Company `gorm:"foreignKey:CompanyID"`
+	UserID    uint     `gorm:"uniqueIndex:idx_company_members_company_user;index;not null"`
+	User      *User    `gorm:"foreignKey:UserID"`
+	Role      string   `gorm:"not null;default:'recruiter'"`
+	CreatedAt time.Time
+}
+
+type Job struct {
+	ID              uint     `gorm:"primaryKey"`
+	Title           string   `gorm:"not null"`
+	Description     string   `gorm:"type:text"`
+	Company         string   `gorm:"not null"`
+	CompanyID       *uint    `gorm:"index"`
+	CompanyProfile  *Company `gorm:"foreignKey:CompanyID"`
+	Location        string
+	WorkMode        string `gorm:"default:'onsite';index"`
+	SalaryMin       *int   `gorm:"index"`
+	SalaryMax       *int   `gorm:"index"`
+	Currency        string `gorm:"size:3;default:'RUB'"`
+	SalaryPeriod    string `gorm:"default:'month'"`
+	Type            string
+	Category        string
+	Requirements    string     `gorm:"type:text"`
+	Benefits        string     `gorm:"type:text"`
+	EmployerID      uint       `gorm:"not null"`
+	Employer        User       `gorm:"foreignKey:EmployerID"`
+	IsActive        bool       `gorm:"default:true"`
+	PublishAt       *time.Time `gorm:"index"`
+	ExpiresAt       *time.Time `gorm:"index"`
+	MaxApplications *int
+	ClosedAt        *time.Time
+	CloseReason     string
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+	DeletedAt       gorm.DeletedAt `gorm:"index"`
+
+	Place
+}
+
+type JobApplication struct {
+	ID        uint   `gorm:"primaryKey"`
+	JobID     uint   `gorm:"not null"`
+	Job       Job    `gorm:"foreignKey:JobID"`
+	UserID    uint   `gorm:"not null"`
+	User      User   `gorm:"foreignKey:UserID"`
+	Status    string `gorm:"index;default:'applied'"`
+	Message   string `gorm:"type:text"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	DeletedAt gorm.DeletedAt `gorm:"index"`
+}
+
+type ApplicationStatusEvent struct {
+	ID            uint `gorm:"primaryKey"`
+	ApplicationID uint `gorm:"index;not null"`
+	FromStatus    string
+	ToStatus      string `gorm:"not null"`
+	ChangedByID   uint   `gorm:"not null"`
+	ChangedBy     User   `gorm:"foreignKey:ChangedByID"`
+	Note          string `gorm:"type:text"`
+	CreatedAt     time.Time
+}
+
+type ApplicationMessage struct {
+	ID            uint                `gorm:"primaryKey"`
+	ApplicationID uint                `gorm:"index;not null"`
+	SenderID      uint                `gorm:"not null"`
+	Sender        User                `gorm:"foreignKey:SenderID"`
+	Body          string              `gorm:"type:text;not null"`
+	Attachments   []MessageAttachment `gorm:"foreignKey:MessageID"`
+	ReadAt        *time.Time
+	CreatedAt     time.Time
+}
+
+type MessageAttachment struct {
+	ID        uint   `gorm:"primaryKey"`
+	MessageID uint   `gorm:"index;not null"`
+	Name      string `gorm:"not null"`
+	URL       string `gorm:"not null"`
+}
+
+type Interview struct {
+	ID               uint            `gorm:"primaryKey"`
+	ApplicationID    uint            `gorm:"index;not null"`
+	Application      *JobApplication `gorm:"foreignKey:ApplicationID"`
+	ScheduledByID    uint            `gorm:"not null"`
+	StartsAt         time.Time       `gorm:"index;not null"`
+	DurationMinutes  int             `gorm:"not null"`
+	Location         string
+	VideoURL         string
+	Status           string `gorm:"index;default:'proposed'"`
+	ProposedStartsAt *time.Time
+	CandidateNote    string `gorm:"type:text"`
+	Sequence         int
+	Interviewers     []User `gorm:"many2many:interview_interviewers"`
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+}
+
+type RefreshToken struct {
+	ID        uint      `gorm:"primaryKey"`
+	UserID    uint      `gorm:"index;not null"`
+	SessionID string    `gorm:"index;not null"`
+	TokenHash string    `gorm:"uniqueIndex;not null"`
+	ExpiresAt time.Time `gorm:"not null"`
+	RevokedAt *time.Time
+	UserAgent string
+	IP        string
+	CreatedAt time.Time
+}
+
+type ActionToken struct {
+	ID        uint      `gorm:"primaryKey"`
+	UserID    uint      `gorm:"index;not null"`
+	Purpose   string    `gorm:"not null"`
+	TokenID   string    `gorm:"uniqueIndex;not null"`
+	ExpiresAt time.Time `gorm:"not null"`
+	UsedAt    *time.Time
+	CreatedAt time.Time
+}
+
+type SavedJob struct {
+	ID        uint `gorm:"primaryKey"`
+	UserID    uint `gorm:"not null;uniqueIndex:idx_saved_jobs_user_job"`
+	JobID     uint `gorm:"not null;uniqueIndex:idx_saved_jobs_user_job;index"`
+	Job       Job  `gorm:"foreignKey:JobID"`
+	CreatedAt time.Time
+}
+
+type SavedSearch struct {
+	ID              uint   `gorm:"primaryKey"`
+	UserID          uint   `gorm:"index;not null"`
+	Name            string `gorm:"not null"`
+	Filter          Filter `gorm:"embedded;embeddedPrefix:filter_"`
+	Frequency       string `gorm:"default:'daily'"`
+	EmailAlerts     bool
+	AlertsCheckedAt time.Time `gorm:"index"`
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+}
+
+// Filter is the saved search filter, stored in the filter_ columns.
+type Filter struct {
+	Search    string
+	Lang      string
+	Category  string
+	Location  string
+	Type      string
+	SalaryMin *int
+	SalaryMax *int
+	Currency  string `gorm:"size:3"`
+	WorkMode  string
+	Country   string `gorm:"size:2"`
+	Near      string
+	Latitude  *float64
+	Longitude *float64
+	RadiusKm  *float64
+}
+
+type Notification struct {
+	ID        uint   `gorm:"primaryKey"`
+	UserID    uint   `gorm:"index;not null"`
+	Type      string `gorm:"not null"`
+	Title     string `gorm:"not null"`
+	Body      string `gorm:"type:text"`
+	Link      string
+	ReadAt    *time.Time
+	CreatedAt time.Time
+}
+
+type Category struct {
+	ID        uint       `gorm:"primaryKey"`
+	Slug      string     `gorm:"uniqueIndex;not null"`
+	ParentID  *uint      `gorm:"index"`
+	NameRu    string     `gorm:"not null"`
+	NameEn    string     `gorm:"not null"`
+	Position  int        `gorm:"default:0"`
+	Children  []Category `gorm:"foreignKey:ParentID"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+type EmploymentType struct {
+	ID        uint   `gorm:"primaryKey"`
+	Slug      string `gorm:"uniqueIndex;not null"`
+	NameRu    string `gorm:"not null"`
+	NameEn    string `gorm:"not null"`
+	Position  int    `gorm:"default:0"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// Models lists every table of the schema in dependency order.
+func Models() []interface{} {
+	return []interface{}{
+		&User{},
+		&UserProfile{},
+		&Company{},
+		&CompanyMember{},
+		&Job{},
+		&JobApplication{},
+		&ApplicationStatusEvent{},
+		&ApplicationMessage{},
+		&MessageAttachment{},
+		&Interview{},
+		&RefreshToken{},
+		&RoleChange{},
+		&ActionToken{},
+		&SavedJob{},
+		&SavedSearch{},
+		&Notification{},
+		&Category{},
+		&EmploymentType{},
+	}
+}
@@ -0,0 +1,93 @@
+package schemav1
+
+import (
+	"io/fs"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"job-search-backend/migrations"
+
+	"gorm.io/gorm/schema"
+)
+
+var (
+	createTable = regexp.MustCompile(`(?s)CREATE TABLE (\w+) \((.*?)\n\);`)
+	createIndex = regexp.MustCompile(`CREATE (?:UNIQUE )?INDEX (\w+) ON (\w+)`)
+)
+
+// legacyOnly are created by the legacy upgrade itself rather than by
+// AutoMigrate.
+var legacyOnly = map[string]bool{
+	"jobs.search_vector":          true,
+	"jobs.idx_jobs_search_vector": true,
+	"jobs.idx_jobs_coordinates":   true,
+}
+
+// TestMatchesInitialMigration checks that AutoMigrating the snapshot gives
+// the tables, columns and indexes of migration 0001, which legacy databases
+// are baselined at.
+func TestMatchesInitialMigration(t *testing.T) {
+	up, err := fs.ReadFile(migrations.FS, "0001_initial_schema.up.sql")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string][]string{}
+	for _, m := range createTable.FindAllStringSubmatch(string(up), -1) {
+		for _, line := range strings.Split(m[2], "\n") {
+			column := strings.Fields(line)
+			if len(column) == 0 || column[0] == "CONSTRAINT" || column[0] == "PRIMARY" {
+				continue
+			}
+			want[m[1]] = append(want[m[1]], column[0])
+		}
+	}
+	for _, m := range createIndex.FindAllStringSubmatch(string(up), -1) {
+		want[m[2]] = append(want[m[2]], m[1])
+	}
+
+	got := map[string][]string{}
+	cache := &sync.Map{}
+	for _, model := range Models() {
+		s, err := schema.Parse(model, cache, schema.NamingStrategy{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		got[s.Table] = append(got[s.Table], s.DBNames...)
+		for _, index := range s.ParseIndexes() {
+			got[s.Table] = append(got[s.Table], index.Name)
+		}
+		for _, rel := range s.Relationships.Relations {
+			if rel.JoinTable != nil {
+				got[rel.JoinTable.Table] = append(got[rel.JoinTable.Table], rel.JoinTable.DBNames...)
+			}
+		}
+	}
+
+	for table, names := range want {
+		var filtered []string
+		for _, name := range names {
+			if !legacyOnly[table+"."+name] {
+				filtered = append(filtered, name)
+			}
+		}
+		want[table] = filtered
+	}
+	if len(got) != len(want) {
+		t.Errorf("got %d tables, migration 0001 has %d", len(got), len(want))
+	}
+	for table, names := range want {
+		if a, b := sorted(got[table]), sorted(names); a != b {
+			t.Errorf("%s:\n  snapshot:  %s\n  migration: %s", table, a, b)
+		}
+	}
+}
+
+func sorted(names []string) string {
+	names = append([]string(nil), names...)
+	sort.Strings(names)
+	return strings.Join(names, " ")
+}
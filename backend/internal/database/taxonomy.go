@@ -0,0 +1,167 @@
+package database
+
+import (
+	"log"
+	"strings"
+
+	"job-search-backend/internal/database/schemav1"
+	"job-search-backend/internal/utils"
+
+	"gorm.io/gorm"
+)
+
+// defaultCategories is created on the first start; admins manage the list afterwards.
+var defaultCategories = []struct {
+	schemav1.Category
+	Parent string
+}{
+	{Category: schemav1.Category{Slug: "it", NameRu: "IT", NameEn: "IT", Position: 1}},
+	{Category: schemav1.Category{Slug: "development", NameRu: "Разработка", NameEn: "Software development", Position: 1}, Parent: "it"},
+	{Category: schemav1.Category{Slug: "qa", NameRu: "Тестирование", NameEn: "QA", Position: 2}, Parent: "it"},
+	{Category: schemav1.Category{Slug: "devops", NameRu: "DevOps", NameEn: "DevOps", Position: 3}, Parent: "it"},
+	{Category: schemav1.Category{Slug: "data", NameRu: "Данные и аналитика", NameEn: "Data and analytics", Position: 4}, Parent: "it"},
+	{Category: schemav1.Category{Slug: "design", NameRu: "Дизайн", NameEn: "Design", Position: 2}},
+	{Category: schemav1.Category{Slug: "marketing", NameRu: "Маркетинг", NameEn: "Marketing", Position: 3}},
+	{Category: schemav1.Category{Slug: "sales", NameRu: "Продажи", NameEn: "Sales", Position: 4}},
+	{Category: schemav1.Category{Slug: "finance", NameRu: "Финансы", NameEn: "Finance", Position: 5}},
+	{Category: schemav1.Category{Slug: "hr", NameRu: "Персонал", NameEn: "HR", Position: 6}},
+	{Category: schemav1.Category{Slug: "management", NameRu: "Управление", NameEn: "Management", Position: 7}},
+	{Category: schemav1.Category{Slug: "other", NameRu: "Другое", NameEn: "Other", Position: 100}},
+}
+
+var defaultEmploymentTypes = []schemav1.EmploymentType{
+	{Slug: "full-time", NameRu: "Полная занятость", NameEn: "Full-time", Position: 1},
+	{Slug: "part-time", NameRu: "Частичная занятость", NameEn: "Part-time", Position: 2},
+	{Slug: "contract", NameRu: "Контракт", NameEn: "Contract", Position: 3},
+	{Slug: "internship", NameRu: "Стажировка", NameEn: "Internship", Position: 4},
+	{Slug: "temporary", NameRu: "Временная работа", NameEn: "Temporary", Position: 5},
+}
+
+// employmentTypeAliases maps common free-text spellings onto type slugs.
+var employmentTypeAliases = map[string]string{
+	"full time":           "full-time",
+	"fulltime":            "full-time",
+	"полная":              "full-time",
+	"полный день":         "full-time",
+	"part time":           "part-time",
+	"parttime":            "part-time",
+	"частичная":           "part-time",
+	"неполный день":       "part-time",
+	"контрактная":         "contract",
+	"проектная работа":    "contract",
+	"стажер":              "internship",
+	"стажёр":              "internship",
+	"временная":           "temporary",
+	"временная занятость": "temporary",
+}
+
+// migrateJobTaxonomy fills the category and employment type reference tables
+// on the first start and replaces the free-text jobs.category and jobs.type
+// values (and the matching saved search filters) with reference slugs.
+// Values that match no entry by slug or name become new entries named after
+// the original text, so that no job loses its category.
+func migrateJobTaxonomy(db *gorm.DB) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		var count int64
+		if err := tx.Model(&schemav1.Category{}).Count(&count).Error; err != nil {
+			return err
+		}
+		if count == 0 {
+			ids := map[string]uint{}
+			for _, item := range defaultCategories {
+				category := item.Category
+				if item.Parent != "" {
+					parentID := ids[item.Parent]
+					category.ParentID = &parentID
+				}
+				if err := tx.Create(&category).Error; err != nil {
+					return err
+				}
+				ids[category.Slug] = category.ID
+			}
+		}
+
+		if err := tx.Model(&schemav1.EmploymentType{}).Count(&count).Error; err != nil {
+			return err
+		}
+		if count == 0 {
+			types := append([]schemav1.EmploymentType{}, defaultEmploymentTypes...)
+			if err := tx.Create(&types).Error; err != nil {
+				return err
+			}
+		}
+
+		err := mapReferenceValues(tx, "categories", "category", nil, func(slug, name string) interface{} {
+			return &schemav1.Category{Slug: slug, NameRu: name, NameEn: name, Position: 50}
+		})
+		if err != nil {
+			return err
+		}
+		return mapReferenceValues(tx, "employment_types", "type", employmentTypeAliases, func(slug, name string) interface{} {
+			return &schemav1.EmploymentType{Slug: slug, NameRu: name, NameEn: name, Position: 50}
+		})
+	})
+}
+
+// mapReferenceValues rewrites the values of jobs.<column> and
+// saved_searches.filter_<column> that are not slugs of table. newEntry builds
+// the entry created for an unknown value.
+func mapReferenceValues(tx *gorm.DB, table, column string, aliases map[string]string, newEntry func(slug, name string) interface{}) error {
+	var values []string
+	if err := tx.Raw(`SELECT DISTINCT ` + column + ` FROM jobs WHERE ` + column + ` <> '' AND ` + column + ` NOT IN (SELECT slug FROM ` + table + `)
+		UNION SELECT DISTINCT filter_` + column + ` FROM saved_searches WHERE filter_` + column + ` <> '' AND filter_` + column + ` NOT IN (SELECT slug FROM ` + table + `)`).
+		Scan(&values).Error; err != nil {
+		return err
+	}
+	if len(values) == 0 {
+		return nil
+	}
+
+	type entry struct {
+		Slug   string
+		NameRu string
+		NameEn string
+	}
+	var entries []entry
+	if err := tx.Table(table).Select("slug, name_ru, name_en").Scan(&entries).Error; err != nil {
+		return err
+	}
+	slugs := map[string]string{}
+	for key, slug := range aliases {
+		slugs[key] = slug
+	}
+	for _, e := range entries {
+		slugs[e.Slug] = e.Slug
+		slugs[strings.ToLower(e.NameRu)] = e.Slug
+		slugs[strings.ToLower(e.NameEn)] = e.Slug
+	}
+
+	for _, value := range values {
+		key := strings.ToLower(strings.TrimSpace(value))
+		slug, ok := slugs[key]
+		if !ok {
+			slug, ok = slugs[utils.Slugify(value)]
+		}
+		if !ok {
+			slug = utils.Slugify(value)
+			if slug == "" {
+				log.Printf("Cannot map %s %q to a reference value, clearing it", column, value)
+			} else {
+				if err := tx.Create(newEntry(slug, strings.TrimSpace(value))).Error; err != nil {
+					return err
+				}
+				log.Printf("Created %s %q for the existing value %q", table, slug, value)
+			}
+			slugs[key] = slug
+			slugs[slug] = slug
+		}
+
+		if err := tx.Exec("UPDATE jobs SET "+column+" = ? WHERE "+column+" = ?", slug, value).Error; err != nil {
+			return err
+		}
+		if err := tx.Exec("UPDATE saved_searches SET filter_"+column+" = ? WHERE filter_"+column+" = ?", slug, value).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,134 @@
+// Package geo resolves city names to coordinates with a small built-in
+// gazetteer, so that locations can be geocoded without calling an external
+// service, and provides the distance helpers used by radius searches.
+package geo
+
+import (
+	_ "embed"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// EarthRadiusKm is the mean Earth radius used for great-circle distances.
+const EarthRadiusKm = 6371.0
+
+// City is a gazetteer entry.
+type City struct {
+	Name      string // Russian name
+	NameEn    string
+	Country   string // ISO 3166-1 alpha-2 code
+	Latitude  float64
+	Longitude float64
+}
+
+//go:embed cities.csv
+var citiesCSV string
+
+var (
+	cities       []City
+	citiesByName = map[string]int{} // normalized name or alias -> index in cities
+)
+
+func init() {
+	for n, line := range strings.Split(citiesCSV, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.Split(line, ";")
+		if len(fields) != 6 {
+			panic(fmt.Sprintf("geo: cities.csv:%d: expected 6 fields", n+1))
+		}
+		lat, err1 := strconv.ParseFloat(fields[3], 64)
+		lon, err2 := strconv.ParseFloat(fields[4], 64)
+		if err1 != nil || err2 != nil {
+			panic(fmt.Sprintf("geo: cities.csv:%d: invalid coordinates", n+1))
+		}
+
+		cities = append(cities, City{Name: fields[0], NameEn: fields[1], Country: fields[2], Latitude: lat, Longitude: lon})
+		names := append([]string{fields[0], fields[1]}, strings.Split(fields[5], "|")...)
+		for _, name := range names {
+			if key := normalize(name); key != "" {
+				citiesByName[key] = len(cities) - 1
+			}
+		}
+	}
+}
+
+// normalize lowercases a name, folds ё into е and drops punctuation and a
+// leading "г." / "город".
+func normalize(s string) string {
+	s = strings.ToLower(strings.TrimSpace(s))
+	s = strings.ReplaceAll(s, "ё", "е")
+	for _, prefix := range []string{"г.", "город ", "г "} {
+		s = strings.TrimPrefix(s, prefix)
+	}
+
+	var b strings.Builder
+	space := false
+	for _, r := range s {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r) || r == '-':
+			b.WriteRune(r)
+			space = false
+		case !space && b.Len() > 0:
+			b.WriteByte(' ')
+			space = true
+		}
+	}
+	return strings.TrimSpace(b.String())
+}
+
+// Lookup finds a city by its Russian or English name or a common alias. A
+// free-text location such as "Москва, Россия" or "Berlin (hybrid)" is also
+// matched by its parts.
+func Lookup(location string) (City, bool) {
+	if i, ok := citiesByName[normalize(location)]; ok {
+		return cities[i], true
+	}
+	parts := strings.FieldsFunc(location, func(r rune) bool {
+		return r == ',' || r == '(' || r == ')' || r == '/' || r == ';' || r == '|'
+	})
+	for _, part := range parts {
+		if i, ok := citiesByName[normalize(part)]; ok {
+			return cities[i], true
+		}
+	}
+	return City{}, false
+}
+
+// DistanceKm returns the great-circle distance between two points.
+func DistanceKm(lat1, lon1, lat2, lon2 float64) float64 {
+	const rad = math.Pi / 180
+	dLat := (lat2 - lat1) * rad
+	dLon := (lon2 - lon1) * rad
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(lat1*rad)*math.Cos(lat2*rad)*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * EarthRadiusKm * math.Asin(math.Min(1, math.Sqrt(a)))
+}
+
+// BoundingBox returns the latitude/longitude box containing every point
+// within radiusKm of the center. wraps is true when the box crosses the
+// antimeridian or a pole, in which case the longitude bounds are meaningless.
+func BoundingBox(lat, lon, radiusKm float64) (minLat, maxLat, minLon, maxLon float64, wraps bool) {
+	dLat := radiusKm / EarthRadiusKm * 180 / math.Pi
+	minLat, maxLat = lat-dLat, lat+dLat
+	if minLat < -90 || maxLat > 90 {
+		return math.Max(minLat, -90), math.Min(maxLat, 90), -180, 180, true
+	}
+
+	dLon := dLat / math.Cos(lat*math.Pi/180)
+	minLon, maxLon = lon-dLon, lon+dLon
+	if minLon < -180 || maxLon > 180 {
+		return minLat, maxLat, -180, 180, true
+	}
+	return minLat, maxLat, minLon, maxLon, false
+}
+
+// ValidCoordinates reports whether lat/lon are within their ranges.
+func ValidCoordinates(lat, lon float64) bool {
+	return lat >= -90 && lat <= 90 && lon >= -180 && lon <= 180
+}
@@ -0,0 +1,31 @@
+package handlers
+
+import (
+	"context"
+
+	"job-search-backend/internal/models"
+	"job-search-backend/internal/repository"
+
+	"gorm.io/gorm"
+)
+
+// canManageJob reports whether the user may edit the job and handle its
+// applications: admins and the current members of the company it is attached
+// to or, for jobs without a company, the employer who posted it. An employer
+// removed from the company loses access to the jobs they posted there.
+func canManageJob(ctx context.Context, jobs repository.JobRepository, userID uint, role interface{}, job *models.Job) bool {
+	if role == models.RoleAdmin {
+		return true
+	}
+	if job.CompanyID == nil {
+		return job.EmployerID == userID
+	}
+	member, err := jobs.IsCompanyMember(ctx, *job.CompanyID, userID)
+	return err == nil && member
+}
+
+// managedJobsScope restricts a query joined with jobs to the jobs canManageJob
+// lets the user handle.
+func managedJobsScope(userID uint) func(*gorm.DB) *gorm.DB {
+	return repository.ManagedJobsScope(userID)
+}
@@ -0,0 +1,176 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"job-search-backend/internal/mail"
+	"job-search-backend/internal/models"
+	"job-search-backend/internal/repository"
+	"job-search-backend/internal/utils"
+
+	"github.com/gin-gonic/gin"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	verifyEmailTTL   = 48 * time.Hour
+	passwordResetTTL = time.Hour
+)
+
+type EmailRequest struct {
+	Email string `json:"email" binding:"required,email"`
+}
+
+type TokenRequest struct {
+	Token string `json:"token" binding:"required"`
+}
+
+type ResetPasswordRequest struct {
+	Token    string `json:"token" binding:"required"`
+	Password string `json:"password" binding:"required,min=6"`
+}
+
+// RequestEmailVerification (re)sends the verification link. The response does
+// not reveal whether the address is registered.
+func (h *AuthHandler) RequestEmailVerification(c *gin.Context) {
+	var req EmailRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if user, err := h.Users.FindByEmail(c.Request.Context(), req.Email); err == nil && user.EmailVerifiedAt == nil {
+		if err := h.sendVerificationEmail(c, *user); err != nil {
+			log.Println("Failed to send verification email:", err)
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "If the address needs confirmation, a link has been sent to it"})
+}
+
+// ConfirmEmail marks the email address as verified.
+func (h *AuthHandler) ConfirmEmail(c *gin.Context) {
+	var req TokenRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userID, tokenID, err := utils.ValidateActionToken(req.Token, models.TokenPurposeVerifyEmail)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid or expired token"})
+		return
+	}
+
+	user, err := h.Users.VerifyEmail(c.Request.Context(), userID, tokenID)
+	if errors.Is(err, repository.ErrInvalidToken) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid or expired token"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to verify email"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Email verified successfully", "user": user})
+}
+
+// ForgotPassword emails a password reset link. The response does not reveal
+// whether the address is registered.
+func (h *AuthHandler) ForgotPassword(c *gin.Context) {
+	var req EmailRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if user, err := h.Users.FindByEmail(c.Request.Context(), req.Email); err == nil {
+		err := h.sendActionEmail(c, *user, models.TokenPurposePasswordReset, passwordResetTTL, "/reset-password",
+			"Сброс пароля",
+			"Чтобы задать новый пароль, перейдите по ссылке (действует 1 час):\n\n%s\n\nЕсли вы не запрашивали сброс пароля, просто проигнорируйте это письмо.\n")
+		if err != nil {
+			log.Println("Failed to send password reset email:", err)
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "If the address is registered, a reset link has been sent to it"})
+}
+
+// ResetPassword sets a new password using a reset token and logs out every
+// existing session of the user.
+func (h *AuthHandler) ResetPassword(c *gin.Context) {
+	var req ResetPasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to hash password"})
+		return
+	}
+
+	userID, tokenID, err := utils.ValidateActionToken(req.Token, models.TokenPurposePasswordReset)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid or expired token"})
+		return
+	}
+
+	err = h.Users.ResetPassword(c.Request.Context(), userID, tokenID, string(hashedPassword))
+	if errors.Is(err, repository.ErrInvalidToken) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid or expired token"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to reset password"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Password has been reset, please log in again"})
+}
+
+func (h *AuthHandler) sendVerificationEmail(c *gin.Context, user models.User) error {
+	return h.sendActionEmail(c, user, models.TokenPurposeVerifyEmail, verifyEmailTTL, "/verify-email",
+		"Подтверждение адреса электронной почты",
+		"Здравствуйте!\n\nЧтобы подтвердить адрес электронной почты, перейдите по ссылке:\n\n%s\n\nСсылка действует 48 часов.\n")
+}
+
+// sendActionEmail issues a single-use token for the given purpose, replacing
+// any unused ones, and emails a link with it to the user. body must contain
+// one %s verb for the link.
+func (h *AuthHandler) sendActionEmail(c *gin.Context, user models.User, purpose string, ttl time.Duration, path, subject, body string) error {
+	if h.Mailer == nil {
+		return errors.New("no mailer configured")
+	}
+
+	tokenID, err := utils.NewRandomID()
+	if err != nil {
+		return err
+	}
+	token, err := utils.GenerateActionToken(user.ID, purpose, tokenID, ttl)
+	if err != nil {
+		return err
+	}
+
+	if err := h.Users.ReplaceActionToken(c.Request.Context(), &models.ActionToken{
+		UserID:    user.ID,
+		Purpose:   purpose,
+		TokenID:   tokenID,
+		ExpiresAt: time.Now().Add(ttl),
+	}); err != nil {
+		return err
+	}
+
+	link := fmt.Sprintf("%s%s?token=%s", strings.TrimRight(h.AppURL, "/"), path, token)
+	return h.Mailer.Send(c.Request.Context(), mail.Message{
+		To:      user.Email,
+		Subject: subject,
+		Body:    fmt.Sprintf(body, link),
+	})
+}
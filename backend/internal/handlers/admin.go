@@ -0,0 +1,177 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"job-search-backend/internal/database"
+	"job-search-backend/internal/models"
+	"job-search-backend/internal/pagination"
+	"job-search-backend/internal/repository"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type AdminHandler struct{}
+
+type UpdateRoleRequest struct {
+	Role   string `json:"role" binding:"required,oneof=job_seeker employer admin"`
+	Reason string `json:"reason" binding:"max=1000"`
+}
+
+// ListUsers returns users, optionally filtered by role and by a name/email search.
+func (h *AdminHandler) ListUsers(c *gin.Context) {
+	page, err := pagination.OffsetFromQuery(c.Request.URL.Query(), 20)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	query := database.DB.Model(&models.User{})
+
+	if role := c.Query("role"); role != "" {
+		query = query.Where("role = ?", role)
+	}
+	if search := strings.TrimSpace(c.Query("search")); search != "" {
+		query = query.Where("name ILIKE ? OR email ILIKE ?", "%"+search+"%", "%"+search+"%")
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch users"})
+		return
+	}
+
+	var users []models.User
+	if err := query.Order("id ASC").Offset(page.Offset).Limit(page.Limit).Find(&users).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch users"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"users": users,
+		"total": total,
+		"page":  page.Number(),
+		"limit": page.Limit,
+	})
+}
+
+var errLastAdmin = errors.New("cannot demote the last admin")
+
+// UpdateUserRole promotes or demotes a user and records the change. The
+// user's sessions are revoked because the role is embedded in access tokens.
+func (h *AdminHandler) UpdateUserRole(c *gin.Context) {
+	adminID, _ := c.Get("userID")
+	targetID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+
+	var req UpdateRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if uint(targetID) == adminID.(uint) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "You cannot change your own role"})
+		return
+	}
+
+	var user models.User
+	if err := database.DB.First(&user, targetID).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
+		return
+	}
+
+	if user.Role == req.Role {
+		c.JSON(http.StatusOK, gin.H{"user": user})
+		return
+	}
+
+	change := models.RoleChange{
+		UserID:      user.ID,
+		ChangedByID: adminID.(uint),
+		OldRole:     user.Role,
+		NewRole:     req.Role,
+		Reason:      req.Reason,
+	}
+
+	err = database.DB.Transaction(func(tx *gorm.DB) error {
+		if user.Role == models.RoleAdmin {
+			// Lock the admins so that two admins demoting each other at once
+			// cannot both see the other one still in place
+			var adminIDs []uint
+			if err := tx.Raw("SELECT id FROM users WHERE role = ? AND deleted_at IS NULL ORDER BY id FOR UPDATE", models.RoleAdmin).
+				Scan(&adminIDs).Error; err != nil {
+				return err
+			}
+			if len(adminIDs) <= 1 {
+				return errLastAdmin
+			}
+		}
+		if err := tx.Model(&user).Update("role", req.Role).Error; err != nil {
+			return err
+		}
+		if err := tx.Create(&change).Error; err != nil {
+			return err
+		}
+		return repository.RevokeSessions(tx.Where("user_id = ?", user.ID))
+	})
+	if errors.Is(err, errLastAdmin) {
+		c.JSON(http.StatusConflict, gin.H{"error": "Cannot demote the last admin"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update role"})
+		return
+	}
+	user.Role = req.Role
+
+	c.JSON(http.StatusOK, gin.H{"user": user, "change": change})
+}
+
+// GetRoleChanges returns a page of the role change audit log, newest first.
+func (h *AdminHandler) GetRoleChanges(c *gin.Context) {
+	page, err := pagination.OffsetFromQuery(c.Request.URL.Query(), 50)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	query := database.DB.Model(&models.RoleChange{})
+	if userID := c.Query("user_id"); userID != "" {
+		id, err := strconv.Atoi(userID)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+			return
+		}
+		query = query.Where("user_id = ?", id)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch role changes"})
+		return
+	}
+
+	var changes []models.RoleChange
+	if err := query.Preload("User").Preload("ChangedBy").
+		Order("created_at DESC, id DESC").
+		Offset(page.Offset).Limit(page.Limit).
+		Find(&changes).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch role changes"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"changes": changes,
+		"total":   total,
+		"page":    page.Number(),
+		"limit":   page.Limit,
+	})
+}
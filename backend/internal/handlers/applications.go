@@ -1,16 +1,31 @@
 package handlers
 
 import (
+	"errors"
+	"fmt"
 	"net/http"
 	"strconv"
 
-	"job-search-backend/internal/database"
 	"job-search-backend/internal/models"
+	"job-search-backend/internal/notifications"
+	"job-search-backend/internal/pagination"
+	"job-search-backend/internal/repository"
 
 	"github.com/gin-gonic/gin"
 )
 
-type ApplicationHandler struct{}
+type ApplicationHandler struct {
+	Applications repository.ApplicationRepository
+	Jobs         repository.JobRepository
+	Users        repository.UserRepository
+	// RequireVerifiedEmail blocks applying until the applicant's email address is confirmed.
+	RequireVerifiedEmail bool
+	Notifications        *notifications.Service
+}
+
+func NewApplicationHandler(applications repository.ApplicationRepository, jobs repository.JobRepository, users repository.UserRepository, notifications *notifications.Service) *ApplicationHandler {
+	return &ApplicationHandler{Applications: applications, Jobs: jobs, Users: users, Notifications: notifications}
+}
 
 type CreateApplicationRequest struct {
 	JobID   uint   `json:"job_id" binding:"required"`
@@ -26,31 +41,48 @@ func (h *ApplicationHandler) CreateApplication(c *gin.Context) {
 		return
 	}
 
-	// Check if job exists
-	var job models.Job
-	if err := database.DB.First(&job, req.JobID).Error; err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Job not found"})
-		return
-	}
-
-	// Check if user already applied
-	var existingApplication models.JobApplication
-	if err := database.DB.Where("job_id = ? AND user_id = ?", req.JobID, userID).First(&existingApplication).Error; err == nil {
-		c.JSON(http.StatusConflict, gin.H{"error": "You have already applied for this job"})
-		return
+	ctx := c.Request.Context()
+	if h.RequireVerifiedEmail {
+		user, err := h.Users.FindByID(ctx, userID.(uint))
+		if err != nil {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "User not found"})
+			return
+		}
+		if user.EmailVerifiedAt == nil {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Please confirm your email address before applying"})
+			return
+		}
 	}
 
 	application := models.JobApplication{
 		JobID:   req.JobID,
 		UserID:  userID.(uint),
 		Message: req.Message,
-		Status:  "pending",
+		Status:  models.ApplicationStatusApplied,
 	}
 
-	if err := database.DB.Create(&application).Error; err != nil {
+	var job models.Job
+	filled, err := h.Applications.Apply(ctx, &application, &job)
+	if errors.Is(err, repository.ErrNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Job not found"})
+		return
+	}
+	if errors.Is(err, repository.ErrAlreadyApplied) {
+		c.JSON(http.StatusConflict, gin.H{"error": "You have already applied for this job"})
+		return
+	}
+	if errors.Is(err, repository.ErrJobClosed) {
+		c.JSON(http.StatusConflict, gin.H{"error": "This job is no longer accepting applications"})
+		return
+	}
+	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create application"})
 		return
 	}
+	notifyApplicationReceived(c, h.Notifications, h.Jobs, h.Users, &job, &application)
+	if filled {
+		notifyJobClosed(c, h.Notifications, &job)
+	}
 
 	c.JSON(http.StatusCreated, gin.H{"application": application})
 }
@@ -58,41 +90,19 @@ func (h *ApplicationHandler) CreateApplication(c *gin.Context) {
 func (h *ApplicationHandler) GetUserApplications(c *gin.Context) {
 	userID, _ := c.Get("userID")
 
-	var applications []models.JobApplication
-	if err := database.DB.Where("user_id = ?", userID).Preload("Job").Preload("Job.Employer").Find(&applications).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch applications"})
-		return
-	}
-
-	c.JSON(http.StatusOK, gin.H{"applications": applications})
+	h.listApplications(c, repository.ApplicationQuery{UserID: userID.(uint)})
 }
 
 func (h *ApplicationHandler) GetEmployerApplications(c *gin.Context) {
 	userID, _ := c.Get("userID")
 
-	// Получаем все заявки на вакансии этого работодателя
-	var applications []models.JobApplication
-	if err := database.DB.Joins("JOIN jobs ON job_applications.job_id = jobs.id").
-		Where("jobs.employer_id = ?", userID).
-		Preload("Job").
-		Preload("User").
-		Find(&applications).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch applications"})
-		return
-	}
-
-	c.JSON(http.StatusOK, gin.H{"applications": applications})
+	// Получаем все заявки на вакансии этого работодателя и его компаний
+	h.listApplications(c, repository.ApplicationQuery{ManagerID: userID.(uint)})
 }
 
 func (h *ApplicationHandler) GetAllApplications(c *gin.Context) {
 	// Получаем все заявки (только для администраторов)
-	var applications []models.JobApplication
-	if err := database.DB.Preload("Job").Preload("User").Find(&applications).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch applications"})
-		return
-	}
-
-	c.JSON(http.StatusOK, gin.H{"applications": applications})
+	h.listApplications(c, repository.ApplicationQuery{})
 }
 
 func (h *ApplicationHandler) GetJobApplications(c *gin.Context) {
@@ -104,25 +114,51 @@ func (h *ApplicationHandler) GetJobApplications(c *gin.Context) {
 	}
 
 	// Check if user is the employer of this job
-	var job models.Job
-	if err := database.DB.First(&job, jobID).Error; err != nil {
+	job, err := h.Jobs.FindByID(c.Request.Context(), uint(jobID))
+	if err != nil {
 		c.JSON(http.StatusNotFound, gin.H{"error": "Job not found"})
 		return
 	}
 
 	role, _ := c.Get("role")
-	if job.EmployerID != userID.(uint) && role != "admin" {
+	if !canManageJob(c.Request.Context(), h.Jobs, userID.(uint), role, job) {
 		c.JSON(http.StatusForbidden, gin.H{"error": "Not authorized to view applications for this job"})
 		return
 	}
 
-	var applications []models.JobApplication
-	if err := database.DB.Where("job_id = ?", jobID).Preload("User").Preload("User.UserProfile").Find(&applications).Error; err != nil {
+	h.listApplications(c, repository.ApplicationQuery{JobID: job.ID})
+}
+
+// listApplications responds with a page of the applications selected by
+// query, newest first, optionally narrowed by the status query parameter.
+func (h *ApplicationHandler) listApplications(c *gin.Context, query repository.ApplicationQuery) {
+	page, err := pagination.FromQuery(c.Request.URL.Query(), pagination.DefaultLimit)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	query.Page = page
+	query.Status = c.Query("status")
+
+	applications, err := h.Applications.List(c.Request.Context(), query)
+	if errors.Is(err, pagination.ErrInvalidCursor) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch applications"})
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"applications": applications})
+	var next string
+	if len(applications) > page.Limit {
+		applications = applications[:page.Limit]
+		next = repository.ApplicationCursor(applications[len(applications)-1])
+	}
+
+	response := gin.H{"applications": applications, "limit": page.Limit}
+	pagination.SetNext(c, response, next)
+	c.JSON(http.StatusOK, response)
 }
 
 func (h *ApplicationHandler) UpdateApplicationStatus(c *gin.Context) {
@@ -134,8 +170,8 @@ func (h *ApplicationHandler) UpdateApplicationStatus(c *gin.Context) {
 		return
 	}
 
-	var application models.JobApplication
-	if err := database.DB.Preload("Job").First(&application, applicationID).Error; err != nil {
+	application, err := h.Applications.FindByID(c.Request.Context(), uint(applicationID))
+	if err != nil {
 		c.JSON(http.StatusNotFound, gin.H{"error": "Application not found"})
 		return
 	}
@@ -146,25 +182,172 @@ func (h *ApplicationHandler) UpdateApplicationStatus(c *gin.Context) {
 		return
 	}
 
-	// Проверяем, что пользователь является работодателем этой вакансии или администратором
-	if application.Job.EmployerID != userID.(uint) && role != "admin" {
+	// Проверяем, что пользователь является работодателем этой вакансии, рекрутером её компании или администратором
+	if !canManageJob(c.Request.Context(), h.Jobs, userID.(uint), role, &application.Job) {
 		c.JSON(http.StatusForbidden, gin.H{"error": "Not authorized to update this application"})
 		return
 	}
 
 	var req struct {
-		Status string `json:"status" binding:"required,oneof=pending accepted rejected"`
+		Status string `json:"status" binding:"required,oneof=screening interview offer hired rejected"`
+		Note   string `json:"note" binding:"max=2000"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if !models.CanTransitionApplication(application.Status, req.Status) {
+		c.JSON(http.StatusConflict, gin.H{
+			"error":   fmt.Sprintf("Cannot change application status from %s to %s", application.Status, req.Status),
+			"allowed": models.AllowedApplicationTransitions(application.Status),
+		})
+		return
+	}
+
+	if err := h.Applications.ChangeStatus(c.Request.Context(), application, req.Status, userID.(uint), req.Note); err != nil {
+		if errors.Is(err, repository.ErrStatusChanged) {
+			c.JSON(http.StatusConflict, gin.H{"error": "Application status was changed concurrently, please retry"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update application"})
+		return
+	}
+	notifyApplicationStatus(c, h.Notifications, h.Jobs, h.Users, &application.Job, application, userID.(uint), req.Note)
+
+	c.JSON(http.StatusOK, gin.H{"application": application})
+}
+
+// GetApplicationHistory returns the status timeline of an application. It is
+// visible to the applicant, the employer of the job and admins.
+func (h *ApplicationHandler) GetApplicationHistory(c *gin.Context) {
+	userID, _ := c.Get("userID")
+	role, _ := c.Get("role")
+	applicationID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid application ID"})
+		return
+	}
+
+	application, err := h.Applications.FindByID(c.Request.Context(), uint(applicationID))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Application not found"})
+		return
+	}
+
+	if application.UserID != userID.(uint) && !canManageJob(c.Request.Context(), h.Jobs, userID.(uint), role, &application.Job) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Not authorized to view this application"})
+		return
+	}
+
+	events, err := h.Applications.History(c.Request.Context(), application.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch application history"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":  application.Status,
+		"allowed": models.AllowedApplicationTransitions(application.Status),
+		"history": events,
+	})
+}
+
+// loadOwnApplication fetches the application from the :id parameter and checks
+// that it belongs to the current user (admins may act on any application).
+func (h *ApplicationHandler) loadOwnApplication(c *gin.Context) (*models.JobApplication, bool) {
+	userID, _ := c.Get("userID")
+	role, _ := c.Get("role")
+	applicationID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid application ID"})
+		return nil, false
+	}
+
+	application, err := h.Applications.FindByID(c.Request.Context(), uint(applicationID))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Application not found"})
+		return nil, false
+	}
+
+	if application.UserID != userID.(uint) && role != "admin" {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Not authorized to modify this application"})
+		return nil, false
+	}
+
+	return application, true
+}
+
+// WithdrawApplication lets the applicant retract an application that is still open.
+func (h *ApplicationHandler) WithdrawApplication(c *gin.Context) {
+	userID, _ := c.Get("userID")
+
+	application, ok := h.loadOwnApplication(c)
+	if !ok {
+		return
+	}
+
+	var req struct {
+		Note string `json:"note" binding:"max=2000"`
+	}
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	if !models.CanTransitionApplication(application.Status, models.ApplicationStatusWithdrawn) {
+		c.JSON(http.StatusConflict, gin.H{"error": fmt.Sprintf("Application with status %s cannot be withdrawn", application.Status)})
+		return
+	}
+
+	if err := h.Applications.ChangeStatus(c.Request.Context(), application, models.ApplicationStatusWithdrawn, userID.(uint), req.Note); err != nil {
+		if errors.Is(err, repository.ErrStatusChanged) {
+			c.JSON(http.StatusConflict, gin.H{"error": "Application status was changed concurrently, please retry"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to withdraw application"})
+		return
+	}
+
+	if job, err := h.Jobs.FindIncludingDeleted(c.Request.Context(), application.JobID); err == nil {
+		notifyApplicationStatus(c, h.Notifications, h.Jobs, h.Users, job, application, userID.(uint), req.Note)
+	}
+
+	c.JSON(http.StatusOK, gin.H{"application": application})
+}
+
+// UpdateApplication lets the applicant edit the cover message while the
+// application has not been reviewed yet.
+func (h *ApplicationHandler) UpdateApplication(c *gin.Context) {
+	application, ok := h.loadOwnApplication(c)
+	if !ok {
+		return
+	}
+
+	var req struct {
+		Message string `json:"message" binding:"max=5000"`
 	}
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
-	application.Status = req.Status
-	if err := database.DB.Save(&application).Error; err != nil {
+	if application.Status != models.ApplicationStatusApplied {
+		c.JSON(http.StatusConflict, gin.H{"error": "Only applications that are still pending can be edited"})
+		return
+	}
+
+	updated, err := h.Applications.UpdateMessage(c.Request.Context(), application, req.Message)
+	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update application"})
 		return
 	}
+	if !updated {
+		c.JSON(http.StatusConflict, gin.H{"error": "Only applications that are still pending can be edited"})
+		return
+	}
 
 	c.JSON(http.StatusOK, gin.H{"application": application})
 }
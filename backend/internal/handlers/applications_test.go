@@ -0,0 +1,150 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"job-search-backend/internal/models"
+	"job-search-backend/internal/repository/memory"
+)
+
+type applicationFixture struct {
+	store     *memory.Store
+	handler   *ApplicationHandler
+	employer  *models.User
+	applicant *models.User
+	job       *models.Job
+}
+
+func newApplicationFixture(t *testing.T) *applicationFixture {
+	t.Helper()
+	ctx := context.Background()
+	store := memory.NewStore()
+	f := &applicationFixture{
+		store:     store,
+		handler:   NewApplicationHandler(store.Applications(), store.Jobs(), store.Users(), nil),
+		employer:  &models.User{Email: "hr@example.com", Name: "HR", Role: models.RoleEmployer},
+		applicant: &models.User{Email: "dev@example.com", Name: "Dev", Role: models.RoleJobSeeker},
+	}
+	for _, user := range []*models.User{f.employer, f.applicant} {
+		if err := store.Users().Create(ctx, user); err != nil {
+			t.Fatal(err)
+		}
+	}
+	f.job = &models.Job{Title: "Go developer", Company: "Acme", EmployerID: f.employer.ID, IsActive: true}
+	if err := store.Jobs().Create(ctx, f.job); err != nil {
+		t.Fatal(err)
+	}
+	return f
+}
+
+func (f *applicationFixture) apply(t *testing.T, as *models.User) (int, models.JobApplication) {
+	var resp struct {
+		Application models.JobApplication `json:"application"`
+	}
+	code := serve(t, f.handler.CreateApplication, http.MethodPost, "/applications", "/applications", as,
+		CreateApplicationRequest{JobID: f.job.ID, Message: "Hello"}, &resp)
+	return code, resp.Application
+}
+
+func TestCreateApplicationRejectsDuplicates(t *testing.T) {
+	f := newApplicationFixture(t)
+
+	if code, application := f.apply(t, f.applicant); code != http.StatusCreated || application.Status != models.ApplicationStatusApplied {
+		t.Fatalf("first application: got %d %+v", code, application)
+	}
+	if code, _ := f.apply(t, f.applicant); code != http.StatusConflict {
+		t.Fatalf("second application: got %d, want %d", code, http.StatusConflict)
+	}
+}
+
+func TestCreateApplicationClosesFilledJob(t *testing.T) {
+	f := newApplicationFixture(t)
+	max := 1
+	f.job.MaxApplications = &max
+	if err := f.store.Jobs().Update(context.Background(), f.job); err != nil {
+		t.Fatal(err)
+	}
+
+	if code, _ := f.apply(t, f.applicant); code != http.StatusCreated {
+		t.Fatalf("got %d, want %d", code, http.StatusCreated)
+	}
+	job, err := f.store.Jobs().FindByID(context.Background(), f.job.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if job.IsActive || job.CloseReason != models.JobCloseReasonFilled {
+		t.Fatalf("job is_active=%v close_reason=%q, want closed as filled", job.IsActive, job.CloseReason)
+	}
+
+	other := &models.User{Email: "qa@example.com", Name: "QA", Role: models.RoleJobSeeker}
+	if err := f.store.Users().Create(context.Background(), other); err != nil {
+		t.Fatal(err)
+	}
+	if code, _ := f.apply(t, other); code != http.StatusConflict {
+		t.Fatalf("applying to a filled job: got %d, want %d", code, http.StatusConflict)
+	}
+}
+
+func TestUpdateApplicationStatus(t *testing.T) {
+	f := newApplicationFixture(t)
+	_, application := f.apply(t, f.applicant)
+	path := fmt.Sprintf("/applications/%d/status", application.ID)
+
+	stranger := &models.User{Email: "other@example.com", Name: "Other", Role: models.RoleEmployer}
+	if err := f.store.Users().Create(context.Background(), stranger); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		as     *models.User
+		status string
+		want   int
+	}{
+		{"applicant", f.applicant, models.ApplicationStatusScreening, http.StatusForbidden},
+		{"employer of another job", stranger, models.ApplicationStatusScreening, http.StatusForbidden},
+		{"transition not allowed", f.employer, models.ApplicationStatusHired, http.StatusConflict},
+		{"employer", f.employer, models.ApplicationStatusScreening, http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code := serve(t, f.handler.UpdateApplicationStatus, http.MethodPut, "/applications/:id/status", path, tt.as,
+				map[string]string{"status": tt.status}, nil)
+			if code != tt.want {
+				t.Fatalf("got %d, want %d", code, tt.want)
+			}
+		})
+	}
+
+	history, err := f.store.Applications().History(context.Background(), application.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(history) != 2 || history[1].FromStatus != models.ApplicationStatusApplied || history[1].ToStatus != models.ApplicationStatusScreening {
+		t.Fatalf("unexpected history %+v", history)
+	}
+}
+
+func TestSendMessageAttachmentLinks(t *testing.T) {
+	f := newApplicationFixture(t)
+	_, application := f.apply(t, f.applicant)
+	path := fmt.Sprintf("/applications/%d/messages", application.ID)
+
+	tests := []struct {
+		url  string
+		want int
+	}{
+		{"javascript:alert(1)", http.StatusBadRequest},
+		{"data:text/html;base64,PHNjcmlwdD4=", http.StatusBadRequest},
+		{"https://example.com/portfolio.pdf", http.StatusCreated},
+	}
+	for _, tt := range tests {
+		body := SendMessageRequest{Body: "See attached", Attachments: []AttachmentRequest{{Name: "portfolio", URL: tt.url}}}
+		if code := serve(t, f.handler.SendMessage, http.MethodPost, "/applications/:id/messages", path, f.applicant, body, nil); code != tt.want {
+			t.Errorf("attachment %q: got %d, want %d", tt.url, code, tt.want)
+		}
+	}
+}
@@ -1,17 +1,32 @@
 package handlers
 
 import (
+	"errors"
+	"log"
 	"net/http"
+	"time"
 
-	"job-search-backend/internal/database"
+	"job-search-backend/internal/mail"
 	"job-search-backend/internal/models"
+	"job-search-backend/internal/repository"
 	"job-search-backend/internal/utils"
 
 	"github.com/gin-gonic/gin"
 	"golang.org/x/crypto/bcrypt"
 )
 
-type AuthHandler struct{}
+type AuthHandler struct {
+	Users  repository.UserRepository
+	Mailer mail.Mailer
+	// AppURL is the frontend base URL used in links sent by email.
+	AppURL string
+	// RequireVerifiedEmail blocks login until the email address is confirmed.
+	RequireVerifiedEmail bool
+}
+
+func NewAuthHandler(users repository.UserRepository, mailer mail.Mailer) *AuthHandler {
+	return &AuthHandler{Users: users, Mailer: mailer}
+}
 
 type LoginRequest struct {
 	Email    string `json:"email" binding:"required,email"`
@@ -22,7 +37,7 @@ type RegisterRequest struct {
 	Email    string `json:"email" binding:"required,email"`
 	Password string `json:"password" binding:"required,min=6"`
 	Name     string `json:"name"`
-	Role     string `json:"role"`
+	Role     string `json:"role" binding:"omitempty,oneof=job_seeker employer"` // admins are appointed via the admin API
 }
 
 func (h *AuthHandler) Register(c *gin.Context) {
@@ -33,8 +48,7 @@ func (h *AuthHandler) Register(c *gin.Context) {
 	}
 
 	// Check if user already exists
-	var existingUser models.User
-	if err := database.DB.Where("email = ?", req.Email).First(&existingUser).Error; err == nil {
+	if _, err := h.Users.FindByEmail(c.Request.Context(), req.Email); err == nil {
 		c.JSON(http.StatusConflict, gin.H{"error": "User already exists"})
 		return
 	}
@@ -48,7 +62,7 @@ func (h *AuthHandler) Register(c *gin.Context) {
 
 	// Set default role
 	if req.Role == "" {
-		req.Role = "job_seeker"
+		req.Role = models.RoleJobSeeker
 	}
 
 	// Create user
@@ -59,22 +73,35 @@ func (h *AuthHandler) Register(c *gin.Context) {
 		Role:     req.Role,
 	}
 
-	if err := database.DB.Create(&user).Error; err != nil {
+	if err := h.Users.Create(c.Request.Context(), &user); err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create user"})
 		return
 	}
 
-	// Generate JWT token
-	token, err := utils.GenerateJWT(user.ID, user.Role)
+	if err := h.sendVerificationEmail(c, user); err != nil {
+		log.Println("Failed to send verification email:", err)
+	}
+
+	if h.RequireVerifiedEmail {
+		c.JSON(http.StatusCreated, gin.H{
+			"message": "User created successfully, please confirm your email address to log in",
+			"user":    user,
+		})
+		return
+	}
+
+	tokens, err := h.startSession(c, user)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate token"})
 		return
 	}
 
 	c.JSON(http.StatusCreated, gin.H{
-		"message": "User created successfully",
-		"token":   token,
-		"user":    user,
+		"message":       "User created successfully",
+		"token":         tokens.AccessToken,
+		"refresh_token": tokens.RefreshToken,
+		"expires_in":    tokens.ExpiresIn,
+		"user":          user,
 	})
 }
 
@@ -86,8 +113,8 @@ func (h *AuthHandler) Login(c *gin.Context) {
 	}
 
 	// Find user
-	var user models.User
-	if err := database.DB.Where("email = ?", req.Email).First(&user).Error; err != nil {
+	user, err := h.Users.FindByEmail(c.Request.Context(), req.Email)
+	if err != nil {
 		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid credentials"})
 		return
 	}
@@ -98,32 +125,152 @@ func (h *AuthHandler) Login(c *gin.Context) {
 		return
 	}
 
-	// Generate JWT token
-	token, err := utils.GenerateJWT(user.ID, user.Role)
+	if h.RequireVerifiedEmail && user.EmailVerifiedAt == nil {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Please confirm your email address before logging in"})
+		return
+	}
+
+	tokens, err := h.startSession(c, *user)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate token"})
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{
-		"message": "Login successful",
-		"token":   token,
-		"user":    user,
+		"message":       "Login successful",
+		"token":         tokens.AccessToken,
+		"refresh_token": tokens.RefreshToken,
+		"expires_in":    tokens.ExpiresIn,
+		"user":          user,
+	})
+}
+
+type RefreshRequest struct {
+	RefreshToken string `json:"refresh_token" binding:"required"`
+}
+
+// Refresh exchanges a refresh token for a new access/refresh token pair. The
+// presented refresh token is revoked; presenting it again is treated as token
+// theft and ends the whole session.
+func (h *AuthHandler) Refresh(c *gin.Context) {
+	var req RefreshRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx := c.Request.Context()
+	stored, err := h.Users.FindRefreshToken(ctx, utils.HashToken(req.RefreshToken))
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid refresh token"})
+		return
+	}
+
+	if stored.RevokedAt != nil {
+		h.Users.RevokeSession(ctx, stored.SessionID)
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Refresh token has already been used"})
+		return
+	}
+	if time.Now().After(stored.ExpiresAt) {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Refresh token has expired"})
+		return
+	}
+
+	user, err := h.Users.FindByID(ctx, stored.UserID)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid refresh token"})
+		return
+	}
+
+	tokens, next, err := newTokenPair(c, *user, stored.SessionID)
+	if err == nil {
+		err = h.Users.RotateRefreshToken(ctx, stored.ID, next)
+	}
+	if errors.Is(err, repository.ErrTokenReused) {
+		h.Users.RevokeSession(ctx, stored.SessionID)
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Refresh token has already been used"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to refresh token"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"token":         tokens.AccessToken,
+		"refresh_token": tokens.RefreshToken,
+		"expires_in":    tokens.ExpiresIn,
 	})
 }
 
-func (h *AuthHandler) GetProfile(c *gin.Context) {
-	userID, exists := c.Get("userID")
-	if !exists {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+// Logout ends the session the current access token belongs to.
+func (h *AuthHandler) Logout(c *gin.Context) {
+	if err := h.Users.RevokeSession(c.Request.Context(), c.GetString("sessionID")); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to log out"})
 		return
 	}
 
-	var user models.User
-	if err := database.DB.Preload("UserProfile").First(&user, userID).Error; err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
+	c.JSON(http.StatusOK, gin.H{"message": "Logged out successfully"})
+}
+
+// LogoutAll ends every session of the current user, on all devices.
+func (h *AuthHandler) LogoutAll(c *gin.Context) {
+	userID, _ := c.Get("userID")
+
+	if err := h.Users.RevokeUserSessions(c.Request.Context(), userID.(uint)); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to log out"})
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"user": user})
+	c.JSON(http.StatusOK, gin.H{"message": "All sessions have been logged out"})
+}
+
+type tokenPair struct {
+	AccessToken  string
+	RefreshToken string
+	ExpiresIn    int
+}
+
+// startSession opens a new login session for the user.
+func (h *AuthHandler) startSession(c *gin.Context, user models.User) (tokenPair, error) {
+	sessionID, err := utils.NewRandomID()
+	if err != nil {
+		return tokenPair{}, err
+	}
+	tokens, refreshToken, err := newTokenPair(c, user, sessionID)
+	if err != nil {
+		return tokenPair{}, err
+	}
+	if err := h.Users.CreateRefreshToken(c.Request.Context(), refreshToken); err != nil {
+		return tokenPair{}, err
+	}
+	return tokens, nil
+}
+
+// newTokenPair signs an access token for the session and generates a refresh
+// token, returning the record the caller has to store for the latter.
+func newTokenPair(c *gin.Context, user models.User, sessionID string) (tokenPair, *models.RefreshToken, error) {
+	refreshToken, hash, err := utils.GenerateRefreshToken()
+	if err != nil {
+		return tokenPair{}, nil, err
+	}
+
+	accessToken, err := utils.GenerateJWT(user.ID, user.Role, sessionID)
+	if err != nil {
+		return tokenPair{}, nil, err
+	}
+
+	tokens := tokenPair{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		ExpiresIn:    int(utils.AccessTokenTTL.Seconds()),
+	}
+	return tokens, &models.RefreshToken{
+		UserID:    user.ID,
+		SessionID: sessionID,
+		TokenHash: hash,
+		ExpiresAt: time.Now().Add(utils.RefreshTokenTTL),
+		UserAgent: c.Request.UserAgent(),
+		IP:        c.ClientIP(),
+	}, nil
 }
@@ -0,0 +1,65 @@
+package handlers
+
+import (
+	"net/http"
+	"testing"
+
+	"job-search-backend/internal/repository/memory"
+	"job-search-backend/internal/utils"
+)
+
+type authResponse struct {
+	Token        string `json:"token"`
+	RefreshToken string `json:"refresh_token"`
+	Error        string `json:"error"`
+}
+
+func TestRegisterAndLogin(t *testing.T) {
+	utils.SetJWTSecret("test-secret")
+	h := NewAuthHandler(memory.NewStore().Users(), nil)
+
+	register := RegisterRequest{Email: "dev@example.com", Password: "secret1", Name: "Dev"}
+	var resp authResponse
+	if code := serve(t, h.Register, http.MethodPost, "/register", "/register", nil, register, &resp); code != http.StatusCreated || resp.Token == "" {
+		t.Fatalf("register: got %d %+v", code, resp)
+	}
+	if code := serve(t, h.Register, http.MethodPost, "/register", "/register", nil, register, nil); code != http.StatusConflict {
+		t.Fatalf("registering twice: got %d, want %d", code, http.StatusConflict)
+	}
+
+	wrong := LoginRequest{Email: register.Email, Password: "wrong"}
+	if code := serve(t, h.Login, http.MethodPost, "/login", "/login", nil, wrong, nil); code != http.StatusUnauthorized {
+		t.Fatalf("wrong password: got %d, want %d", code, http.StatusUnauthorized)
+	}
+	login := LoginRequest{Email: register.Email, Password: register.Password}
+	if code := serve(t, h.Login, http.MethodPost, "/login", "/login", nil, login, &resp); code != http.StatusOK || resp.RefreshToken == "" {
+		t.Fatalf("login: got %d %+v", code, resp)
+	}
+}
+
+func TestRefreshTokenReuseEndsSession(t *testing.T) {
+	utils.SetJWTSecret("test-secret")
+	h := NewAuthHandler(memory.NewStore().Users(), nil)
+
+	var first authResponse
+	serve(t, h.Register, http.MethodPost, "/register", "/register", nil,
+		RegisterRequest{Email: "dev@example.com", Password: "secret1"}, &first)
+
+	refresh := func(token string) (int, authResponse) {
+		var resp authResponse
+		code := serve(t, h.Refresh, http.MethodPost, "/refresh", "/refresh", nil, RefreshRequest{RefreshToken: token}, &resp)
+		return code, resp
+	}
+
+	code, second := refresh(first.RefreshToken)
+	if code != http.StatusOK || second.RefreshToken == "" || second.RefreshToken == first.RefreshToken {
+		t.Fatalf("refresh: got %d %+v", code, second)
+	}
+	if code, _ := refresh(first.RefreshToken); code != http.StatusUnauthorized {
+		t.Fatalf("reusing a refresh token: got %d, want %d", code, http.StatusUnauthorized)
+	}
+	// The reuse revoked the whole session, including the rotated token
+	if code, _ := refresh(second.RefreshToken); code != http.StatusUnauthorized {
+		t.Fatalf("refresh after reuse: got %d, want %d", code, http.StatusUnauthorized)
+	}
+}
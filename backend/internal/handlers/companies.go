@@ -0,0 +1,339 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"job-search-backend/internal/database"
+	"job-search-backend/internal/models"
+	"job-search-backend/internal/repository"
+	"job-search-backend/internal/utils"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type CompanyHandler struct {
+	Jobs repository.JobRepository
+}
+
+type CompanyRequest struct {
+	Name        string `json:"name" binding:"required,max=200"`
+	Description string `json:"description" binding:"max=10000"`
+	Website     string `json:"website" binding:"omitempty,http_url,max=255"`
+	LogoURL     string `json:"logo_url" binding:"omitempty,http_url,max=500"`
+	Location    string `json:"location" binding:"max=255"`
+	Size        string `json:"size" binding:"omitempty,oneof=1-10 11-50 51-200 201-500 501-1000 1000+"`
+}
+
+type AddMemberRequest struct {
+	Email string `json:"email" binding:"required,email"`
+	Role  string `json:"role" binding:"omitempty,oneof=owner recruiter"`
+}
+
+// GetCompanies lists companies, optionally filtered by name.
+func (h *CompanyHandler) GetCompanies(c *gin.Context) {
+	query := database.DB.Model(&models.Company{})
+	if search := strings.TrimSpace(c.Query("search")); search != "" {
+		query = query.Where("name ILIKE ?", "%"+search+"%")
+	}
+
+	var companies []models.Company
+	if err := query.Order("name ASC").Limit(100).Find(&companies).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch companies"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"companies": companies})
+}
+
+// GetCompany returns the public company page: the profile and its active jobs.
+// The :id parameter may be either the numeric ID or the slug.
+func (h *CompanyHandler) GetCompany(c *gin.Context) {
+	company, ok := h.loadCompany(c)
+	if !ok {
+		return
+	}
+
+	var jobs []models.Job
+	if err := database.DB.Where("company_id = ? AND is_active = ?", company.ID, true).
+		Order("created_at DESC").
+		Find(&jobs).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch jobs"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"company": company, "jobs": jobs})
+}
+
+// GetMyCompanies returns the companies the current user is a member of.
+func (h *CompanyHandler) GetMyCompanies(c *gin.Context) {
+	userID, _ := c.Get("userID")
+
+	var memberships []models.CompanyMember
+	if err := database.DB.Where("user_id = ?", userID).Preload("Company").Find(&memberships).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch companies"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"memberships": memberships})
+}
+
+// CreateCompany creates a company with the current user as its owner.
+func (h *CompanyHandler) CreateCompany(c *gin.Context) {
+	userID, _ := c.Get("userID")
+
+	var req CompanyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	company := models.Company{
+		Name:        strings.TrimSpace(req.Name),
+		Description: req.Description,
+		Website:     req.Website,
+		LogoURL:     req.LogoURL,
+		Location:    req.Location,
+		Size:        req.Size,
+	}
+
+	err := database.DB.Transaction(func(tx *gorm.DB) error {
+		slug, err := uniqueCompanySlug(tx, company.Name)
+		if err != nil {
+			return err
+		}
+		company.Slug = slug
+
+		if err := tx.Create(&company).Error; err != nil {
+			return err
+		}
+		return tx.Create(&models.CompanyMember{
+			CompanyID: company.ID,
+			UserID:    userID.(uint),
+			Role:      models.CompanyRoleOwner,
+		}).Error
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create company"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"company": company})
+}
+
+// UpdateCompany edits the company profile. Only owners and admins may do it.
+func (h *CompanyHandler) UpdateCompany(c *gin.Context) {
+	company, ok := h.loadCompany(c)
+	if !ok || !h.requireCompanyOwner(c, company) {
+		return
+	}
+
+	var req CompanyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	company.Name = strings.TrimSpace(req.Name)
+	company.Description = req.Description
+	company.Website = req.Website
+	company.LogoURL = req.LogoURL
+	company.Location = req.Location
+	company.Size = req.Size
+
+	err := database.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(company).Error; err != nil {
+			return err
+		}
+		// Keep the denormalized company name on the company's jobs in sync
+		return tx.Model(&models.Job{}).Where("company_id = ?", company.ID).Update("company", company.Name).Error
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update company"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"company": company})
+}
+
+// GetMembers lists the members of a company. Visible to its members and admins.
+func (h *CompanyHandler) GetMembers(c *gin.Context) {
+	userID, _ := c.Get("userID")
+	role, _ := c.Get("role")
+
+	company, ok := h.loadCompany(c)
+	if !ok {
+		return
+	}
+	if role != models.RoleAdmin {
+		member, err := h.Jobs.IsCompanyMember(c.Request.Context(), company.ID, userID.(uint))
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check membership"})
+			return
+		}
+		if !member {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Not authorized to view members of this company"})
+			return
+		}
+	}
+
+	var members []models.CompanyMember
+	if err := database.DB.Where("company_id = ?", company.ID).Preload("User").Order("id ASC").Find(&members).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch members"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"members": members})
+}
+
+// AddMember adds an existing employer account to the company.
+func (h *CompanyHandler) AddMember(c *gin.Context) {
+	company, ok := h.loadCompany(c)
+	if !ok || !h.requireCompanyOwner(c, company) {
+		return
+	}
+
+	var req AddMemberRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if req.Role == "" {
+		req.Role = models.CompanyRoleRecruiter
+	}
+
+	var user models.User
+	if err := database.DB.Where("email = ?", req.Email).First(&user).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
+		return
+	}
+	if user.Role != models.RoleEmployer && user.Role != models.RoleAdmin {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Only employer accounts can join a company"})
+		return
+	}
+
+	joined, err := h.Jobs.IsCompanyMember(c.Request.Context(), company.ID, user.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check membership"})
+		return
+	}
+	if joined {
+		c.JSON(http.StatusConflict, gin.H{"error": "User is already a member of this company"})
+		return
+	}
+
+	member := models.CompanyMember{CompanyID: company.ID, UserID: user.ID, Role: req.Role}
+	if err := database.DB.Create(&member).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to add member"})
+		return
+	}
+	member.User = &user
+
+	c.JSON(http.StatusCreated, gin.H{"member": member})
+}
+
+// RemoveMember removes a user from the company. Owners may remove anyone but
+// the last owner; any member may leave the company themselves.
+func (h *CompanyHandler) RemoveMember(c *gin.Context) {
+	userID, _ := c.Get("userID")
+
+	company, ok := h.loadCompany(c)
+	if !ok {
+		return
+	}
+	memberID, err := strconv.Atoi(c.Param("userId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+	if uint(memberID) != userID.(uint) && !h.requireCompanyOwner(c, company) {
+		return
+	}
+
+	err = h.Jobs.RemoveCompanyMember(c.Request.Context(), company.ID, uint(memberID))
+	if errors.Is(err, repository.ErrNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Member not found"})
+		return
+	}
+	if errors.Is(err, repository.ErrLastOwner) {
+		c.JSON(http.StatusConflict, gin.H{"error": "Cannot remove the last owner of the company"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to remove member"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Member removed successfully"})
+}
+
+// loadCompany fetches the company from the :id parameter, which may be a
+// numeric ID or a slug.
+func (h *CompanyHandler) loadCompany(c *gin.Context) (*models.Company, bool) {
+	company := &models.Company{}
+	var err error
+	if id, convErr := strconv.Atoi(c.Param("id")); convErr == nil {
+		company, err = h.Jobs.FindCompany(c.Request.Context(), uint(id))
+	} else {
+		err = database.DB.Where("slug = ?", c.Param("id")).First(company).Error
+	}
+
+	if errors.Is(err, repository.ErrNotFound) || errors.Is(err, gorm.ErrRecordNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Company not found"})
+		return nil, false
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch company"})
+		return nil, false
+	}
+	return company, true
+}
+
+// requireCompanyOwner checks that the user is an admin or an owner of the
+// company, writing the error response itself when they are not.
+func (h *CompanyHandler) requireCompanyOwner(c *gin.Context, company *models.Company) bool {
+	userID, _ := c.Get("userID")
+	role, _ := c.Get("role")
+	if role == models.RoleAdmin {
+		return true
+	}
+	owner, err := h.Jobs.IsCompanyMember(c.Request.Context(), company.ID, userID.(uint), models.CompanyRoleOwner)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check membership"})
+		return false
+	}
+	if !owner {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Only company owners can do this"})
+		return false
+	}
+	return true
+}
+
+// slugify turns a company name into a URL-friendly slug.
+func slugify(name string) string {
+	slug := utils.Slugify(name)
+	if _, err := strconv.Atoi(slug); err == nil || slug == "" {
+		// Purely numeric slugs would be mistaken for IDs
+		slug = strings.Trim("company-"+slug, "-")
+	}
+	return slug
+}
+
+func uniqueCompanySlug(tx *gorm.DB, name string) (string, error) {
+	base := slugify(name)
+	slug := base
+	for i := 2; ; i++ {
+		var count int64
+		if err := tx.Unscoped().Model(&models.Company{}).Where("slug = ?", slug).Count(&count).Error; err != nil {
+			return "", err
+		}
+		if count == 0 {
+			return slug, nil
+		}
+		slug = fmt.Sprintf("%s-%d", base, i)
+	}
+}
@@ -0,0 +1,23 @@
+package handlers
+
+import (
+	"net/http"
+	"testing"
+
+	"job-search-backend/internal/models"
+)
+
+func TestCompanyLinksMustBeHTTP(t *testing.T) {
+	handler := &CompanyHandler{}
+	employer := &models.User{ID: 1, Role: models.RoleEmployer}
+
+	for _, body := range []CompanyRequest{
+		{Name: "Acme", Website: "javascript:alert(1)"},
+		{Name: "Acme", Website: "ftp://acme.example.com"},
+		{Name: "Acme", LogoURL: "data:image/svg+xml;base64,PHN2Zz4="},
+	} {
+		if code := serve(t, handler.CreateCompany, http.MethodPost, "/companies", "/companies", employer, body, nil); code != http.StatusBadRequest {
+			t.Errorf("website %q, logo %q: got %d, want %d", body.Website, body.LogoURL, code, http.StatusBadRequest)
+		}
+	}
+}
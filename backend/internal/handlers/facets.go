@@ -0,0 +1,113 @@
+package handlers
+
+import (
+	"net/http"
+	"sort"
+
+	"job-search-backend/internal/jobfilter"
+	"job-search-backend/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// maxFacetValues limits the values returned per facet; locations are free
+// text and can have a long tail.
+const maxFacetValues = 20
+
+// salaryThresholds are the "from" bounds of the salary facet per currency,
+// for monthly salaries.
+var salaryThresholds = map[string][]int{
+	"RUB": {30000, 50000, 100000, 150000, 200000, 300000},
+	"USD": {1000, 2000, 3000, 5000, 7000},
+	"EUR": {1000, 2000, 3000, 5000, 7000},
+}
+
+type FacetValue struct {
+	Value string `json:"value"`
+	Count int64  `json:"count"`
+}
+
+// SalaryBucket counts the jobs that pay at least Min, i.e. the jobs
+// GET /api/jobs returns with salary_min=Min.
+type SalaryBucket struct {
+	Min   int   `json:"min"`
+	Count int64 `json:"count"`
+}
+
+type SalaryFacet struct {
+	Currency     string         `json:"currency"`
+	Period       string         `json:"period"`
+	Buckets      []SalaryBucket `json:"buckets"`
+	NotSpecified int64          `json:"not_specified"`
+}
+
+// GetJobFacets returns the number of active jobs per category, type,
+// location, work mode and salary bucket among the jobs matching the GET /api/jobs
+// filters, so that filter options can show how many results they lead to.
+func (h *JobHandler) GetJobFacets(c *gin.Context) {
+	filter, err := jobfilter.FromQuery(c.Request.URL.Query())
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	currency := filter.Currency
+	if currency == "" {
+		currency = utils.DefaultCurrency
+	}
+	period := filter.SalaryPeriod
+	if period == "" {
+		period = utils.SalaryPeriodMonth
+	}
+	// Hourly and yearly salaries have no buckets
+	var thresholds []int
+	if period == utils.SalaryPeriodMonth {
+		thresholds = salaryThresholds[currency]
+	}
+
+	facets, err := h.Jobs.Facets(c.Request.Context(), filter, currency, period, thresholds)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to compute facets"})
+		return
+	}
+
+	salary := SalaryFacet{
+		Currency:     currency,
+		Period:       period,
+		Buckets:      make([]SalaryBucket, len(thresholds)),
+		NotSpecified: facets.SalaryNotSpecified,
+	}
+	for i, threshold := range thresholds {
+		salary.Buckets[i] = SalaryBucket{Min: threshold, Count: facets.SalaryCounts[i]}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"total":      facets.Total,
+		"categories": topFacetValues(facets.Categories),
+		"types":      topFacetValues(facets.Types),
+		"locations":  topFacetValues(facets.Locations),
+		"work_modes": topFacetValues(facets.WorkModes),
+		"salary":     salary,
+	})
+}
+
+// topFacetValues orders the values by count and keeps the most frequent
+// ones. Empty values are skipped.
+func topFacetValues(counts map[string]int64) []FacetValue {
+	values := []FacetValue{}
+	for value, count := range counts {
+		if value != "" {
+			values = append(values, FacetValue{Value: value, Count: count})
+		}
+	}
+	sort.Slice(values, func(i, j int) bool {
+		if values[i].Count != values[j].Count {
+			return values[i].Count > values[j].Count
+		}
+		return values[i].Value < values[j].Value
+	})
+	if len(values) > maxFacetValues {
+		values = values[:maxFacetValues]
+	}
+	return values
+}
@@ -0,0 +1,49 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+
+	"job-search-backend/internal/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+func init() {
+	gin.SetMode(gin.TestMode)
+}
+
+// serve runs handler for one request to path, routed by pattern, as the
+// given user (anonymous when nil), and decodes the JSON response into out.
+func serve(t *testing.T, handler gin.HandlerFunc, method, pattern, path string, as *models.User, body interface{}, out interface{}) int {
+	t.Helper()
+
+	r := gin.New()
+	r.Handle(method, pattern, func(c *gin.Context) {
+		if as != nil {
+			c.Set("userID", as.ID)
+			c.Set("role", as.Role)
+		}
+		c.Next()
+	}, handler)
+
+	var payload bytes.Buffer
+	if body != nil {
+		if err := json.NewEncoder(&payload).Encode(body); err != nil {
+			t.Fatal(err)
+		}
+	}
+	req := httptest.NewRequest(method, path, &payload)
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if out != nil {
+		if err := json.Unmarshal(w.Body.Bytes(), out); err != nil {
+			t.Fatalf("%s %s: decoding %q: %v", method, path, w.Body.String(), err)
+		}
+	}
+	return w.Code
+}
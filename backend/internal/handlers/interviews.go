@@ -0,0 +1,595 @@
+package handlers
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"job-search-backend/internal/database"
+	"job-search-backend/internal/ical"
+	"job-search-backend/internal/models"
+	"job-search-backend/internal/notifications"
+	"job-search-backend/internal/repository"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type InterviewHandler struct {
+	Jobs          repository.JobRepository
+	Applications  repository.ApplicationRepository
+	Users         repository.UserRepository
+	Notifications *notifications.Service
+}
+
+type InterviewRequest struct {
+	StartsAt        time.Time `json:"starts_at" binding:"required"`
+	DurationMinutes int       `json:"duration_minutes" binding:"required,min=15,max=480"`
+	Location        string    `json:"location" binding:"max=255"`
+	VideoURL        string    `json:"video_url" binding:"omitempty,http_url,max=500"`
+	InterviewerIDs  []uint    `json:"interviewer_ids" binding:"max=10"`
+	IgnoreConflicts bool      `json:"ignore_conflicts"`
+}
+
+type InterviewResponseRequest struct {
+	Action           string     `json:"action" binding:"required,oneof=accept decline propose"`
+	ProposedStartsAt *time.Time `json:"proposed_starts_at"`
+	Note             string     `json:"note" binding:"max=2000"`
+}
+
+// interviewTimeLayout formats interview times in notifications.
+const interviewTimeLayout = "02.01.2006 15:04 MST"
+
+// openInterviewStatuses are the statuses in which an interview blocks the
+// interviewers' time.
+var openInterviewStatuses = []string{
+	models.InterviewStatusProposed,
+	models.InterviewStatusAccepted,
+	models.InterviewStatusRescheduleRequested,
+}
+
+// validate checks the request and that the interviewers are able to manage
+// the job. It writes the error response itself and returns false when the
+// request cannot proceed.
+func (r *InterviewRequest) validate(c *gin.Context, jobs repository.JobRepository, job *models.Job) bool {
+	userID, _ := c.Get("userID")
+
+	if !r.StartsAt.After(time.Now()) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "starts_at must be in the future"})
+		return false
+	}
+	r.Location = strings.TrimSpace(r.Location)
+	if r.Location == "" && r.VideoURL == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "location or video_url is required"})
+		return false
+	}
+
+	if len(r.InterviewerIDs) == 0 {
+		r.InterviewerIDs = []uint{userID.(uint)}
+	}
+	managers, err := jobManagerIDs(c.Request.Context(), jobs, job)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch interviewers"})
+		return false
+	}
+	allowed := map[uint]bool{userID.(uint): true}
+	for _, id := range managers {
+		allowed[id] = true
+	}
+	for _, id := range r.InterviewerIDs {
+		if !allowed[id] {
+			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("User %d cannot interview for this job", id)})
+			return false
+		}
+	}
+
+	return true
+}
+
+// errInterviewChanged aborts an update of an interview whose status or
+// sequence another request changed since it was read.
+var errInterviewChanged = errors.New("interview changed concurrently")
+
+// updateInterview saves the given columns of the interview unless its status
+// and sequence are no longer the ones read, in which case it fails with
+// errInterviewChanged.
+func updateInterview(db *gorm.DB, interview *models.Interview, readStatus string, readSequence int, columns ...string) error {
+	result := db.Model(interview).
+		Where("status = ? AND sequence = ?", readStatus, readSequence).
+		Select(columns).
+		Updates(interview)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errInterviewChanged
+	}
+	return nil
+}
+
+// interviewConflictError aborts a booking whose slot overlaps other
+// interviews of the interviewers.
+type interviewConflictError struct {
+	conflicts []models.Interview
+}
+
+func (e *interviewConflictError) Error() string {
+	return "the interviewers have other interviews at this time"
+}
+
+// book saves the interview in a transaction that first locks the
+// interviewers' rows, so that concurrent bookings of the same interviewers
+// run one after another, and then checks the slot for conflicts with their
+// other interviews. It writes the error response itself, using failure for
+// unexpected errors, and returns false when the interview was not saved.
+func (r *InterviewRequest) book(c *gin.Context, excludeID uint, failure string, save func(tx *gorm.DB, interviewers []models.User) error) bool {
+	err := database.DB.Transaction(func(tx *gorm.DB) error {
+		var interviewers []models.User
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id IN ?", r.InterviewerIDs).
+			Order("id").
+			Find(&interviewers).Error; err != nil {
+			return err
+		}
+
+		if !r.IgnoreConflicts {
+			end := r.StartsAt.Add(time.Duration(r.DurationMinutes) * time.Minute)
+			conflicts, err := findInterviewConflicts(tx, r.InterviewerIDs, r.StartsAt, end, excludeID)
+			if err != nil {
+				return err
+			}
+			if len(conflicts) > 0 {
+				return &interviewConflictError{conflicts: conflicts}
+			}
+		}
+
+		return save(tx, interviewers)
+	})
+
+	if errors.Is(err, errInterviewChanged) {
+		c.JSON(http.StatusConflict, gin.H{"error": "Interview was changed concurrently, please retry"})
+		return false
+	}
+	var conflict *interviewConflictError
+	if errors.As(err, &conflict) {
+		c.JSON(http.StatusConflict, gin.H{
+			"error":     "The interviewers have other interviews at this time",
+			"conflicts": conflict.conflicts,
+		})
+		return false
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": failure})
+		return false
+	}
+	return true
+}
+
+// findInterviewConflicts returns the open interviews of any of the users that
+// overlap the given time range.
+func findInterviewConflicts(db *gorm.DB, userIDs []uint, start, end time.Time, excludeID uint) ([]models.Interview, error) {
+	var conflicts []models.Interview
+	err := db.
+		Where("interviews.status IN ? AND interviews.id <> ?", openInterviewStatuses, excludeID).
+		Where("interviews.starts_at < ? AND interviews.starts_at + interviews.duration_minutes * INTERVAL '1 minute' > ?", end, start).
+		Where("interviews.id IN (SELECT interview_id FROM interview_interviewers WHERE user_id IN ?)", userIDs).
+		Preload("Interviewers").
+		Order("interviews.starts_at ASC").
+		Find(&conflicts).Error
+	return conflicts, err
+}
+
+// ScheduleInterview offers an interview slot to the candidate of an
+// application. Applications that are not yet at the interview stage are moved
+// to it.
+func (h *InterviewHandler) ScheduleInterview(c *gin.Context) {
+	userID, _ := c.Get("userID")
+	role, _ := c.Get("role")
+	applicationID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid application ID"})
+		return
+	}
+
+	application, err := h.Applications.FindByID(c.Request.Context(), uint(applicationID))
+	if errors.Is(err, repository.ErrNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Application not found"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch application"})
+		return
+	}
+	if !canManageJob(c.Request.Context(), h.Jobs, userID.(uint), role, &application.Job) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Not authorized to schedule interviews for this application"})
+		return
+	}
+	if len(models.AllowedApplicationTransitions(application.Status)) == 0 {
+		c.JSON(http.StatusConflict, gin.H{"error": fmt.Sprintf("Cannot schedule an interview for an application with status %s", application.Status)})
+		return
+	}
+
+	var req InterviewRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if !req.validate(c, h.Jobs, &application.Job) {
+		return
+	}
+
+	interview := models.Interview{
+		ApplicationID:   application.ID,
+		ScheduledByID:   userID.(uint),
+		StartsAt:        req.StartsAt,
+		DurationMinutes: req.DurationMinutes,
+		Location:        req.Location,
+		VideoURL:        req.VideoURL,
+		Status:          models.InterviewStatusProposed,
+	}
+	if !req.book(c, 0, "Failed to schedule interview", func(tx *gorm.DB, interviewers []models.User) error {
+		interview.Interviewers = interviewers
+		return tx.Create(&interview).Error
+	}) {
+		return
+	}
+
+	if models.CanTransitionApplication(application.Status, models.ApplicationStatusInterview) {
+		// A concurrent status change is not a reason to fail the interview itself
+		if err := h.Applications.ChangeStatus(c.Request.Context(), application, models.ApplicationStatusInterview, userID.(uint), ""); err == nil {
+			notifyApplicationStatus(c, h.Notifications, h.Jobs, h.Users, &application.Job, application, userID.(uint), "")
+		}
+	}
+
+	interview.Application = application
+	h.notifyCandidate(c, &interview, "Приглашение на собеседование")
+
+	c.JSON(http.StatusCreated, gin.H{"interview": interview})
+}
+
+// GetApplicationInterviews lists the interviews of an application. Visible to
+// the same users as its messages.
+func (h *InterviewHandler) GetApplicationInterviews(c *gin.Context) {
+	application, ok := loadApplicationThread(c, h.Applications, h.Jobs)
+	if !ok {
+		return
+	}
+
+	var interviews []models.Interview
+	if err := database.DB.Where("application_id = ?", application.ID).
+		Preload("Interviewers").
+		Order("starts_at ASC").
+		Find(&interviews).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch interviews"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"interviews": interviews})
+}
+
+// GetMyInterviews lists the interviews the user attends as a candidate or an
+// interviewer, from now on unless from/to (RFC 3339) are given.
+func (h *InterviewHandler) GetMyInterviews(c *gin.Context) {
+	userID, _ := c.Get("userID")
+
+	from := time.Now()
+	if value := c.Query("from"); value != "" {
+		parsed, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid from, expected RFC 3339"})
+			return
+		}
+		from = parsed
+	}
+
+	query := database.DB.
+		Joins("JOIN job_applications ON job_applications.id = interviews.application_id").
+		Where("job_applications.user_id = ? OR interviews.id IN (SELECT interview_id FROM interview_interviewers WHERE user_id = ?)", userID, userID).
+		Where("interviews.starts_at >= ?", from)
+	if value := c.Query("to"); value != "" {
+		to, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid to, expected RFC 3339"})
+			return
+		}
+		query = query.Where("interviews.starts_at < ?", to)
+	}
+
+	var interviews []models.Interview
+	if err := query.Preload("Application.Job").Preload("Interviewers").
+		Order("interviews.starts_at ASC").
+		Limit(200).
+		Find(&interviews).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch interviews"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"interviews": interviews})
+}
+
+// RescheduleInterview changes the slot, for example after the candidate
+// proposed another time. The candidate has to respond again.
+func (h *InterviewHandler) RescheduleInterview(c *gin.Context) {
+	interview, ok := h.loadManagedInterview(c)
+	if !ok {
+		return
+	}
+	if !interview.IsOpen() {
+		c.JSON(http.StatusConflict, gin.H{"error": fmt.Sprintf("Interview with status %s cannot be rescheduled", interview.Status)})
+		return
+	}
+
+	var req InterviewRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if !req.validate(c, h.Jobs, &interview.Application.Job) {
+		return
+	}
+
+	readStatus, readSequence := interview.Status, interview.Sequence
+	interview.StartsAt = req.StartsAt
+	interview.DurationMinutes = req.DurationMinutes
+	interview.Location = req.Location
+	interview.VideoURL = req.VideoURL
+	interview.Status = models.InterviewStatusProposed
+	interview.ProposedStartsAt = nil
+	interview.CandidateNote = ""
+	interview.Sequence++
+
+	if !req.book(c, interview.ID, "Failed to reschedule interview", func(tx *gorm.DB, interviewers []models.User) error {
+		if err := updateInterview(tx, interview, readStatus, readSequence,
+			"starts_at", "duration_minutes", "location", "video_url", "status", "proposed_starts_at", "candidate_note", "sequence"); err != nil {
+			return err
+		}
+		return tx.Model(interview).Association("Interviewers").Replace(interviewers)
+	}) {
+		return
+	}
+
+	h.notifyCandidate(c, interview, "Собеседование перенесено")
+
+	c.JSON(http.StatusOK, gin.H{"interview": interview})
+}
+
+func (h *InterviewHandler) CancelInterview(c *gin.Context) {
+	interview, ok := h.loadManagedInterview(c)
+	if !ok {
+		return
+	}
+	if !interview.IsOpen() {
+		c.JSON(http.StatusConflict, gin.H{"error": fmt.Sprintf("Interview with status %s cannot be cancelled", interview.Status)})
+		return
+	}
+
+	readStatus, readSequence := interview.Status, interview.Sequence
+	interview.Status = models.InterviewStatusCancelled
+	interview.Sequence++
+	err := updateInterview(database.DB, interview, readStatus, readSequence, "status", "sequence")
+	if errors.Is(err, errInterviewChanged) {
+		c.JSON(http.StatusConflict, gin.H{"error": "Interview was changed concurrently, please retry"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to cancel interview"})
+		return
+	}
+
+	h.notifyCandidate(c, interview, "Собеседование отменено")
+
+	c.JSON(http.StatusOK, gin.H{"interview": interview})
+}
+
+// RespondToInterview lets the candidate accept or decline the slot or
+// propose another time.
+func (h *InterviewHandler) RespondToInterview(c *gin.Context) {
+	userID, _ := c.Get("userID")
+
+	interview, ok := loadInterview(c)
+	if !ok {
+		return
+	}
+	if interview.Application.UserID != userID.(uint) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Only the candidate can respond to an interview"})
+		return
+	}
+
+	var req InterviewResponseRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if !interview.IsOpen() {
+		c.JSON(http.StatusConflict, gin.H{"error": fmt.Sprintf("Interview with status %s cannot be answered", interview.Status)})
+		return
+	}
+
+	readStatus, readSequence := interview.Status, interview.Sequence
+	var title string
+	switch req.Action {
+	case "accept":
+		if interview.Status == models.InterviewStatusRescheduleRequested {
+			c.JSON(http.StatusConflict, gin.H{"error": "Wait for the employer to answer your proposed time"})
+			return
+		}
+		interview.Status = models.InterviewStatusAccepted
+		title = "Кандидат подтвердил собеседование"
+	case "decline":
+		// Calendars only apply the cancellation of a newer sequence
+		interview.Status = models.InterviewStatusDeclined
+		interview.Sequence++
+		title = "Кандидат отказался от собеседования"
+	case "propose":
+		if req.ProposedStartsAt == nil || !req.ProposedStartsAt.After(time.Now()) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "proposed_starts_at in the future is required"})
+			return
+		}
+		interview.Status = models.InterviewStatusRescheduleRequested
+		interview.ProposedStartsAt = req.ProposedStartsAt
+		title = "Кандидат предложил другое время собеседования"
+	}
+	interview.CandidateNote = req.Note
+
+	err := updateInterview(database.DB, interview, readStatus, readSequence, "status", "proposed_starts_at", "candidate_note", "sequence")
+	if errors.Is(err, errInterviewChanged) {
+		c.JSON(http.StatusConflict, gin.H{"error": "Interview was changed concurrently, please retry"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update interview"})
+		return
+	}
+
+	body := fmt.Sprintf("Вакансия «%s», %s", interview.Application.Job.Title, interview.StartsAt.Format(interviewTimeLayout))
+	if req.Action == "propose" {
+		body += fmt.Sprintf("\nПредложенное время: %s", interview.ProposedStartsAt.Format(interviewTimeLayout))
+	}
+	if req.Note != "" {
+		body += "\n\n" + req.Note
+	}
+	notes := make([]models.Notification, 0, len(interview.Interviewers)+1)
+	recipients := map[uint]bool{interview.ScheduledByID: true}
+	for _, interviewer := range interview.Interviewers {
+		recipients[interviewer.ID] = true
+	}
+	for id := range recipients {
+		notes = append(notes, models.Notification{
+			UserID: id,
+			Type:   models.NotificationInterview,
+			Title:  title,
+			Body:   body,
+			Link:   "/applications",
+		})
+	}
+	sendNotifications(c, h.Notifications, notes...)
+
+	c.JSON(http.StatusOK, gin.H{"interview": interview})
+}
+
+// DownloadInvite serves the interview as an .ics file for calendar apps.
+func (h *InterviewHandler) DownloadInvite(c *gin.Context) {
+	userID, _ := c.Get("userID")
+	role, _ := c.Get("role")
+
+	interview, ok := loadInterview(c)
+	if !ok {
+		return
+	}
+	if interview.Application.UserID != userID.(uint) && !canManageJob(c.Request.Context(), h.Jobs, userID.(uint), role, &interview.Application.Job) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Not authorized to view this interview"})
+		return
+	}
+
+	candidate, err := h.Users.FindByID(c.Request.Context(), interview.Application.UserID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch candidate"})
+		return
+	}
+	organizer, err := h.Users.FindByID(c.Request.Context(), interview.ScheduledByID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch organizer"})
+		return
+	}
+
+	job := interview.Application.Job
+	event := ical.Event{
+		UID:       fmt.Sprintf("interview-%d@job-search", interview.ID),
+		Sequence:  interview.Sequence,
+		Start:     interview.StartsAt,
+		End:       interview.EndsAt(),
+		Summary:   fmt.Sprintf("Собеседование: %s (%s)", job.Title, job.Company),
+		Location:  interview.Location,
+		URL:       interview.VideoURL,
+		Organizer: &ical.Person{Name: organizer.Name, Email: organizer.Email},
+		Attendees: []ical.Person{{Name: candidate.Name, Email: candidate.Email}},
+		Updated:   interview.UpdatedAt,
+	}
+	if interview.VideoURL != "" {
+		event.Description = "Ссылка на видеозвонок: " + interview.VideoURL
+		if event.Location == "" {
+			event.Location = interview.VideoURL
+		}
+	}
+	for _, interviewer := range interview.Interviewers {
+		event.Attendees = append(event.Attendees, ical.Person{Name: interviewer.Name, Email: interviewer.Email})
+	}
+
+	method := ical.MethodRequest
+	switch interview.Status {
+	case models.InterviewStatusAccepted:
+		event.Status = ical.StatusConfirmed
+	case models.InterviewStatusCancelled, models.InterviewStatusDeclined:
+		event.Status = ical.StatusCancelled
+		method = ical.MethodCancel
+	default:
+		event.Status = ical.StatusTentative
+	}
+
+	var buf bytes.Buffer
+	if err := ical.Write(&buf, method, event); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate invite"})
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="interview-%d.ics"`, interview.ID))
+	c.Data(http.StatusOK, "text/calendar; charset=utf-8; method="+method, buf.Bytes())
+}
+
+func loadInterview(c *gin.Context) (*models.Interview, bool) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid interview ID"})
+		return nil, false
+	}
+
+	var interview models.Interview
+	err = database.DB.Preload("Application.Job").Preload("Interviewers").First(&interview, id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) || (err == nil && interview.Application == nil) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Interview not found"})
+		return nil, false
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch interview"})
+		return nil, false
+	}
+	return &interview, true
+}
+
+// loadManagedInterview loads the interview and checks that the user may manage its job.
+func (h *InterviewHandler) loadManagedInterview(c *gin.Context) (*models.Interview, bool) {
+	userID, _ := c.Get("userID")
+	role, _ := c.Get("role")
+
+	interview, ok := loadInterview(c)
+	if !ok {
+		return nil, false
+	}
+	if !canManageJob(c.Request.Context(), h.Jobs, userID.(uint), role, &interview.Application.Job) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Not authorized to manage this interview"})
+		return nil, false
+	}
+	return interview, true
+}
+
+func (h *InterviewHandler) notifyCandidate(c *gin.Context, interview *models.Interview, title string) {
+	body := fmt.Sprintf("Вакансия «%s», %s", interview.Application.Job.Title, interview.StartsAt.Format(interviewTimeLayout))
+	if interview.Location != "" {
+		body += "\n" + interview.Location
+	}
+	if interview.VideoURL != "" {
+		body += "\n" + interview.VideoURL
+	}
+	sendNotifications(c, h.Notifications, models.Notification{
+		UserID: interview.Application.UserID,
+		Type:   models.NotificationInterview,
+		Title:  title,
+		Body:   body,
+		Link:   "/applications",
+	})
+}
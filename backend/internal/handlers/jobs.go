@@ -1,27 +1,173 @@
 package handlers
 
 import (
+	"context"
+	"errors"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
-	"job-search-backend/internal/database"
+	"job-search-backend/internal/jobfilter"
 	"job-search-backend/internal/models"
+	"job-search-backend/internal/notifications"
+	"job-search-backend/internal/pagination"
+	"job-search-backend/internal/repository"
+	"job-search-backend/internal/utils"
 
 	"github.com/gin-gonic/gin"
 )
 
-type JobHandler struct{}
+type JobHandler struct {
+	Jobs          repository.JobRepository
+	Notifications *notifications.Service
+}
+
+func NewJobHandler(jobs repository.JobRepository, notifications *notifications.Service) *JobHandler {
+	return &JobHandler{Jobs: jobs, Notifications: notifications}
+}
 
 type CreateJobRequest struct {
 	Title        string `json:"title" binding:"required"`
 	Description  string `json:"description" binding:"required"`
-	Company      string `json:"company" binding:"required"`
+	Company      string `json:"company"` // required unless company_id is given
+	CompanyID    *uint  `json:"company_id"`
 	Location     string `json:"location"`
-	Salary       string `json:"salary"`
-	Type         string `json:"type"`
-	Category     string `json:"category"`
+	WorkMode     string `json:"work_mode" binding:"omitempty,oneof=onsite remote hybrid"`
+	SalaryMin    *int   `json:"salary_min" binding:"omitempty,min=0"`
+	SalaryMax    *int   `json:"salary_max" binding:"omitempty,min=0"`
+	Currency     string `json:"currency" binding:"omitempty,oneof=RUB USD EUR"`
+	SalaryPeriod string `json:"salary_period" binding:"omitempty,oneof=hour month year"`
+	Salary       string `json:"salary"`   // legacy free-text salary, parsed when salary_min/salary_max are absent
+	Type         string `json:"type"`     // employment type slug
+	Category     string `json:"category"` // category slug
 	Requirements string `json:"requirements"`
 	Benefits     string `json:"benefits"`
+	IsActive     *bool  `json:"is_active"` // defaults to true on create, unchanged on update
+
+	// Structured location; geocoded from city or location when coordinates are omitted
+	City      string   `json:"city" binding:"max=100"`
+	Country   string   `json:"country" binding:"omitempty,len=2"`
+	Latitude  *float64 `json:"latitude" binding:"omitempty,min=-90,max=90"`
+	Longitude *float64 `json:"longitude" binding:"omitempty,min=-180,max=180"`
+
+	PublishAt       *time.Time `json:"publish_at"`
+	ExpiresAt       *time.Time `json:"expires_at"`
+	MaxApplications *int       `json:"max_applications" binding:"omitempty,min=1"`
+}
+
+// validateSchedule checks the publication window. current is the job's
+// stored expires_at on update: a past one may be sent back unchanged.
+func (r *CreateJobRequest) validateSchedule(current *time.Time) error {
+	if r.ExpiresAt == nil {
+		return nil
+	}
+	changed := current == nil || !current.Equal(*r.ExpiresAt)
+	if changed && !r.ExpiresAt.After(time.Now()) {
+		return errors.New("expires_at must be in the future")
+	}
+	if r.PublishAt != nil && !r.ExpiresAt.After(*r.PublishAt) {
+		return errors.New("expires_at must be after publish_at")
+	}
+	return nil
+}
+
+// validateReferences checks that category and type are slugs of existing
+// categories and employment types. Both are optional.
+func (r *CreateJobRequest) validateReferences(ctx context.Context, jobs repository.JobRepository) error {
+	r.Category = strings.TrimSpace(r.Category)
+	r.Type = strings.TrimSpace(r.Type)
+
+	if r.Category != "" {
+		if ok, _ := jobs.CategoryExists(ctx, r.Category); !ok {
+			return errors.New("Unknown category, see GET /api/categories")
+		}
+	}
+	if r.Type != "" {
+		if ok, _ := jobs.EmploymentTypeExists(ctx, r.Type); !ok {
+			return errors.New("Unknown employment type, see GET /api/employment-types")
+		}
+	}
+	return nil
+}
+
+// normalizePlace resolves the structured location and the work mode. The
+// work mode defaults to what the free-text location suggests, else onsite.
+func (r *CreateJobRequest) normalizePlace() (models.Place, error) {
+	if (r.Latitude == nil) != (r.Longitude == nil) {
+		return models.Place{}, errors.New("latitude and longitude must be given together")
+	}
+	place := models.Place{
+		City:      strings.TrimSpace(r.City),
+		Country:   strings.ToUpper(strings.TrimSpace(r.Country)),
+		Latitude:  r.Latitude,
+		Longitude: r.Longitude,
+	}
+	place.Geocode(r.Location)
+
+	if r.WorkMode == "" {
+		r.WorkMode = models.GuessWorkMode(r.Location)
+	}
+	if r.WorkMode == "" {
+		r.WorkMode = models.WorkModeOnsite
+	}
+	return place, nil
+}
+
+// normalizeSalary fills the structured salary fields from the legacy text
+// field when needed and validates the resulting range.
+func (r *CreateJobRequest) normalizeSalary() error {
+	if r.SalaryMin == nil && r.SalaryMax == nil && r.Salary != "" {
+		parsed, ok := utils.ParseSalary(r.Salary)
+		if !ok {
+			return errors.New("Could not parse salary")
+		}
+		r.SalaryMin, r.SalaryMax = parsed.Min, parsed.Max
+		if r.Currency == "" {
+			r.Currency = parsed.Currency
+		}
+		if r.SalaryPeriod == "" {
+			r.SalaryPeriod = parsed.Period
+		}
+	}
+	if r.SalaryMin != nil && r.SalaryMax != nil && *r.SalaryMin > *r.SalaryMax {
+		return errors.New("salary_min cannot be greater than salary_max")
+	}
+	if r.Currency == "" {
+		r.Currency = utils.DefaultCurrency
+	}
+	if r.SalaryPeriod == "" {
+		r.SalaryPeriod = utils.SalaryPeriodMonth
+	}
+	return nil
+}
+
+// resolveCompany checks that the user may post on behalf of the requested
+// company and takes the company name from it. It writes the error response
+// itself and returns false when the request cannot proceed.
+func (r *CreateJobRequest) resolveCompany(c *gin.Context, jobs repository.JobRepository, userID uint, role interface{}) bool {
+	if r.CompanyID == nil {
+		if strings.TrimSpace(r.Company) == "" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "company or company_id is required"})
+			return false
+		}
+		return true
+	}
+
+	company, err := jobs.FindCompany(c.Request.Context(), *r.CompanyID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Company not found"})
+		return false
+	}
+	if role != models.RoleAdmin {
+		if member, _ := jobs.IsCompanyMember(c.Request.Context(), company.ID, userID); !member {
+			c.JSON(http.StatusForbidden, gin.H{"error": "You are not a member of this company"})
+			return false
+		}
+	}
+
+	r.Company = company.Name
+	return true
 }
 
 func (h *JobHandler) CreateJob(c *gin.Context) {
@@ -32,22 +178,57 @@ func (h *JobHandler) CreateJob(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
+	if err := req.normalizeSalary(); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err := req.validateSchedule(nil); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err := req.validateReferences(c.Request.Context(), h.Jobs); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	place, err := req.normalizePlace()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	role, _ := c.Get("role")
+	if !req.resolveCompany(c, h.Jobs, userID.(uint), role) {
+		return
+	}
 
 	job := models.Job{
 		Title:        req.Title,
 		Description:  req.Description,
 		Company:      req.Company,
+		CompanyID:    req.CompanyID,
 		Location:     req.Location,
-		Salary:       req.Salary,
+		WorkMode:     req.WorkMode,
+		Place:        place,
+		SalaryMin:    req.SalaryMin,
+		SalaryMax:    req.SalaryMax,
+		Currency:     req.Currency,
+		SalaryPeriod: req.SalaryPeriod,
 		Type:         req.Type,
 		Category:     req.Category,
 		Requirements: req.Requirements,
 		Benefits:     req.Benefits,
 		EmployerID:   userID.(uint),
-		IsActive:     true,
+		IsActive:     req.IsActive == nil || *req.IsActive,
+
+		PublishAt:       req.PublishAt,
+		ExpiresAt:       req.ExpiresAt,
+		MaxApplications: req.MaxApplications,
+	}
+	// Postings scheduled for later are published by the scheduler
+	if job.PublishAt != nil && job.PublishAt.After(time.Now()) {
+		job.IsActive = false
 	}
 
-	if err := database.DB.Create(&job).Error; err != nil {
+	if err := h.Jobs.Create(c.Request.Context(), &job); err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create job"})
 		return
 	}
@@ -56,59 +237,89 @@ func (h *JobHandler) CreateJob(c *gin.Context) {
 }
 
 func (h *JobHandler) GetJobs(c *gin.Context) {
-	var jobs []models.Job
-	query := database.DB.Where("is_active = ?", true).Preload("Employer")
-
-	// Filter by category
-	if category := c.Query("category"); category != "" {
-		query = query.Where("category = ?", category)
+	filter, err := jobfilter.FromQuery(c.Request.URL.Query())
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
 	}
 
-	// Filter by location
-	if location := c.Query("location"); location != "" {
-		query = query.Where("location ILIKE ?", "%"+location+"%")
-	}
+	listJobs(c, h.Jobs, filter)
+}
 
-	// Filter by type
-	if jobType := c.Query("type"); jobType != "" {
-		query = query.Where("type = ?", jobType)
-	}
+// listJobs responds with a page of active jobs matching the filter.
+func listJobs(c *gin.Context, jobs repository.JobRepository, filter jobfilter.Filter) {
+	pageJobs(c, jobs, filter, true)
+}
 
-	// Search by title or description
-	if search := c.Query("search"); search != "" {
-		query = query.Where("title ILIKE ? OR description ILIKE ?", "%"+search+"%", "%"+search+"%")
+// pageJobs responds with a page of the jobs matching the filter, using the
+// limit, cursor (or legacy page) and sort query parameters. relevance is the
+// default sort when searching, newest otherwise. Salary sorts only list jobs
+// paid in the filter's currency and period, like salary bounds.
+func pageJobs(c *gin.Context, jobs repository.JobRepository, filter jobfilter.Filter, activeOnly bool) {
+	page, err := pagination.FromQuery(c.Request.URL.Query(), 10)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
 	}
 
-	// Pagination
-	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
-	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
-	offset := (page - 1) * limit
-
-	var total int64
-	query.Model(&models.Job{}).Count(&total)
+	sort := c.Query("sort")
+	if sort == "" {
+		sort = "newest"
+		if filter.Search != "" {
+			sort = "relevance"
+		}
+	}
+	if sort == "salary_desc" || sort == "salary_asc" {
+		filter.DefaultSalaryUnit()
+	}
+	order, ok := repository.JobOrder(sort, filter)
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid sort option, expected newest, salary_desc, salary_asc, title, relevance with search or distance with near"})
+		return
+	}
 
-	if err := query.Offset(offset).Limit(limit).Find(&jobs).Error; err != nil {
+	list, total, err := jobs.List(c.Request.Context(), repository.JobQuery{
+		Filter:     filter,
+		ActiveOnly: activeOnly,
+		Order:      order,
+		Page:       page,
+	})
+	if errors.Is(err, pagination.ErrInvalidCursor) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch jobs"})
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{
-		"jobs":  jobs,
+	var next string
+	if len(list) > page.Limit {
+		list = list[:page.Limit]
+		next = repository.JobCursor(order, list[len(list)-1])
+	}
+	markSavedJobs(c, jobs, list)
+
+	response := gin.H{
+		"jobs":  list,
 		"total": total,
-		"page":  page,
-		"limit": limit,
-	})
+		"limit": page.Limit,
+		"sort":  order.Name,
+	}
+	pagination.SetNext(c, response, next)
+	c.JSON(http.StatusOK, response)
 }
 
+// GetAllJobs lists every job including inactive ones (admins only). It takes
+// the same filters, sorting and pagination as GetJobs.
 func (h *JobHandler) GetAllJobs(c *gin.Context) {
-	// Получаем все вакансии (только для администраторов)
-	var jobs []models.Job
-	if err := database.DB.Preload("Employer").Find(&jobs).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch jobs"})
+	filter, err := jobfilter.FromQuery(c.Request.URL.Query())
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"jobs": jobs})
+	pageJobs(c, h.Jobs, filter, false)
 }
 
 func (h *JobHandler) GetJob(c *gin.Context) {
@@ -118,13 +329,15 @@ func (h *JobHandler) GetJob(c *gin.Context) {
 		return
 	}
 
-	var job models.Job
-	if err := database.DB.Preload("Employer").First(&job, id).Error; err != nil {
+	job, err := h.Jobs.FindWithDetails(c.Request.Context(), uint(id))
+	if err != nil {
 		c.JSON(http.StatusNotFound, gin.H{"error": "Job not found"})
 		return
 	}
+	jobs := []models.Job{*job}
+	markSavedJobs(c, h.Jobs, jobs)
 
-	c.JSON(http.StatusOK, gin.H{"job": job})
+	c.JSON(http.StatusOK, gin.H{"job": jobs[0]})
 }
 
 func (h *JobHandler) UpdateJob(c *gin.Context) {
@@ -135,15 +348,15 @@ func (h *JobHandler) UpdateJob(c *gin.Context) {
 		return
 	}
 
-	var job models.Job
-	if err := database.DB.First(&job, id).Error; err != nil {
+	job, err := h.Jobs.FindByID(c.Request.Context(), uint(id))
+	if err != nil {
 		c.JSON(http.StatusNotFound, gin.H{"error": "Job not found"})
 		return
 	}
 
-	// Check if user is the employer or admin
+	// Check if user is the employer, a recruiter of the job's company or admin
 	role, _ := c.Get("role")
-	if job.EmployerID != userID.(uint) && role != "admin" {
+	if !canManageJob(c.Request.Context(), h.Jobs, userID.(uint), role, job) {
 		c.JSON(http.StatusForbidden, gin.H{"error": "Not authorized to update this job"})
 		return
 	}
@@ -153,23 +366,73 @@ func (h *JobHandler) UpdateJob(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
+	if err := req.normalizeSalary(); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err := req.validateSchedule(job.ExpiresAt); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err := req.validateReferences(c.Request.Context(), h.Jobs); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	place, err := req.normalizePlace()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if !req.resolveCompany(c, h.Jobs, userID.(uint), role) {
+		return
+	}
 
 	job.Title = req.Title
 	job.Description = req.Description
 	job.Company = req.Company
+	job.CompanyID = req.CompanyID
 	job.Location = req.Location
-	job.Salary = req.Salary
+	job.WorkMode = req.WorkMode
+	job.Place = place
+	job.SalaryMin = req.SalaryMin
+	job.SalaryMax = req.SalaryMax
+	job.Currency = req.Currency
+	job.SalaryPeriod = req.SalaryPeriod
 	job.Type = req.Type
 	job.Category = req.Category
 	job.Requirements = req.Requirements
 	job.Benefits = req.Benefits
+	job.PublishAt = req.PublishAt
+	job.ExpiresAt = req.ExpiresAt
+	job.MaxApplications = req.MaxApplications
+
+	// Opening and closing go through the same rules as the close/reopen
+	// endpoints. A job that is not closed follows publish_at like on create:
+	// a future one holds the job back for the scheduler, otherwise the job is
+	// published. Opening is checked before saving so that a refused reopen
+	// leaves the edits unsaved too.
+	closing := req.IsActive != nil && !*req.IsActive
+	opening := !closing && !job.IsActive && (job.ClosedAt == nil || req.IsActive != nil)
+	publishLater := job.PublishAt != nil && job.PublishAt.After(time.Now())
+	if opening && !h.checkReopen(c, job) {
+		return
+	}
 
-	if err := database.DB.Save(&job).Error; err != nil {
+	if err := h.Jobs.Update(c.Request.Context(), job); err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update job"})
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"job": job})
+	switch {
+	case closing && job.IsActive:
+		h.close(c, job)
+	case opening && !publishLater:
+		h.saveReopened(c, job)
+	case (opening || job.IsActive) && publishLater:
+		h.saveScheduled(c, job)
+	default:
+		c.JSON(http.StatusOK, gin.H{"job": job})
+	}
 }
 
 func (h *JobHandler) DeleteJob(c *gin.Context) {
@@ -180,23 +443,170 @@ func (h *JobHandler) DeleteJob(c *gin.Context) {
 		return
 	}
 
-	var job models.Job
-	if err := database.DB.First(&job, id).Error; err != nil {
+	job, err := h.Jobs.FindByID(c.Request.Context(), uint(id))
+	if err != nil {
 		c.JSON(http.StatusNotFound, gin.H{"error": "Job not found"})
 		return
 	}
 
-	// Check if user is the employer or admin
+	// Check if user is the employer, a recruiter of the job's company or admin
 	role, _ := c.Get("role")
-	if job.EmployerID != userID.(uint) && role != "admin" {
+	if !canManageJob(c.Request.Context(), h.Jobs, userID.(uint), role, job) {
 		c.JSON(http.StatusForbidden, gin.H{"error": "Not authorized to delete this job"})
 		return
 	}
 
-	if err := database.DB.Delete(&job).Error; err != nil {
+	if err := h.Jobs.Delete(c.Request.Context(), job); err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete job"})
 		return
 	}
+	if job.IsActive {
+		notifyJobClosed(c, h.Notifications, job)
+	}
 
 	c.JSON(http.StatusOK, gin.H{"message": "Job deleted successfully"})
 }
+
+type ReopenJobRequest struct {
+	ExpiresAt       *time.Time `json:"expires_at"`
+	MaxApplications *int       `json:"max_applications" binding:"omitempty,min=1"`
+}
+
+// CloseJob stops the job from accepting applications. It also cancels a
+// publication that is still scheduled.
+func (h *JobHandler) CloseJob(c *gin.Context) {
+	job, ok := h.loadManagedJob(c)
+	if !ok {
+		return
+	}
+	h.close(c, job)
+}
+
+// ReopenJob opens a closed job again. The body may extend expires_at or raise
+// max_applications when those are what closed it.
+func (h *JobHandler) ReopenJob(c *gin.Context) {
+	job, ok := h.loadManagedJob(c)
+	if !ok {
+		return
+	}
+
+	var req ReopenJobRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+	if req.ExpiresAt != nil {
+		job.ExpiresAt = req.ExpiresAt
+	}
+	if req.MaxApplications != nil {
+		job.MaxApplications = req.MaxApplications
+	}
+
+	h.reopen(c, job)
+}
+
+func (h *JobHandler) close(c *gin.Context, job *models.Job) {
+	closed, err := h.Jobs.Close(c.Request.Context(), job, models.JobCloseReasonManual, time.Now())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to close job"})
+		return
+	}
+	if !closed {
+		c.JSON(http.StatusConflict, gin.H{"error": "Job is already closed"})
+		return
+	}
+	notifyJobClosed(c, h.Notifications, job)
+
+	c.JSON(http.StatusOK, gin.H{"job": job})
+}
+
+func (h *JobHandler) reopen(c *gin.Context, job *models.Job) {
+	if h.checkReopen(c, job) {
+		h.saveReopened(c, job)
+	}
+}
+
+// checkReopen reports whether the job may be opened again. It writes the
+// error response itself when it may not.
+func (h *JobHandler) checkReopen(c *gin.Context, job *models.Job) bool {
+	if job.IsActive {
+		c.JSON(http.StatusConflict, gin.H{"error": "Job is already open"})
+		return false
+	}
+
+	if job.ExpiresAt != nil && !job.ExpiresAt.After(time.Now()) {
+		c.JSON(http.StatusConflict, gin.H{"error": "Job has expired, set a later expires_at to reopen it"})
+		return false
+	}
+	if job.MaxApplications != nil {
+		count, err := h.Jobs.CountApplications(c.Request.Context(), job.ID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to count applications"})
+			return false
+		}
+		if count >= int64(*job.MaxApplications) {
+			c.JSON(http.StatusConflict, gin.H{"error": "Job has reached max_applications, raise it to reopen"})
+			return false
+		}
+	}
+	return true
+}
+
+// saveReopened opens the job, which checkReopen has allowed.
+func (h *JobHandler) saveReopened(c *gin.Context, job *models.Job) {
+	// Reopening publishes right away, even if the job was scheduled for later
+	now := time.Now()
+	if job.PublishAt != nil && job.PublishAt.After(now) {
+		job.PublishAt = &now
+	}
+	job.IsActive = true
+	job.ClosedAt = nil
+	job.CloseReason = ""
+
+	if err := h.Jobs.Reopen(c.Request.Context(), job); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to reopen job"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"job": job})
+}
+
+// saveScheduled holds the job back until the scheduler publishes it at its
+// publish_at, which is in the future.
+func (h *JobHandler) saveScheduled(c *gin.Context, job *models.Job) {
+	job.IsActive = false
+	job.ClosedAt = nil
+	job.CloseReason = ""
+
+	if err := h.Jobs.Reopen(c.Request.Context(), job); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update job"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"job": job})
+}
+
+// loadManagedJob fetches the job from the :id parameter and checks that the
+// user may manage it.
+func (h *JobHandler) loadManagedJob(c *gin.Context) (*models.Job, bool) {
+	userID, _ := c.Get("userID")
+	role, _ := c.Get("role")
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid job ID"})
+		return nil, false
+	}
+
+	job, err := h.Jobs.FindByID(c.Request.Context(), uint(id))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Job not found"})
+		return nil, false
+	}
+	if !canManageJob(c.Request.Context(), h.Jobs, userID.(uint), role, job) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Not authorized to manage this job"})
+		return nil, false
+	}
+	return job, true
+}
@@ -0,0 +1,22 @@
+package handlers
+
+import (
+	"testing"
+	"time"
+)
+
+func TestValidateScheduleKeepsPastExpiry(t *testing.T) {
+	past := time.Now().Add(-time.Hour)
+	req := CreateJobRequest{ExpiresAt: &past}
+
+	if err := req.validateSchedule(nil); err == nil {
+		t.Error("creating with a past expires_at: want an error")
+	}
+	if err := req.validateSchedule(&past); err != nil {
+		t.Errorf("sending back the stored expires_at: %v", err)
+	}
+	earlier := past.Add(-time.Hour)
+	if err := req.validateSchedule(&earlier); err == nil {
+		t.Error("changing expires_at to a past time: want an error")
+	}
+}
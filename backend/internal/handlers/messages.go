@@ -0,0 +1,141 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"job-search-backend/internal/models"
+	"job-search-backend/internal/pagination"
+	"job-search-backend/internal/repository"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AttachmentRequest struct {
+	Name string `json:"name" binding:"required,max=255"`
+	URL  string `json:"url" binding:"required,http_url,max=2000"`
+}
+
+type SendMessageRequest struct {
+	Body        string              `json:"body" binding:"required,max=5000"`
+	Attachments []AttachmentRequest `json:"attachments" binding:"max=5,dive"`
+}
+
+// loadApplicationThread fetches the application from the :id parameter and
+// checks that the user takes part in its conversation: the applicant, or
+// anyone allowed to view the job's applications.
+func loadApplicationThread(c *gin.Context, applications repository.ApplicationRepository, jobs repository.JobRepository) (*models.JobApplication, bool) {
+	userID, _ := c.Get("userID")
+	role, _ := c.Get("role")
+	applicationID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid application ID"})
+		return nil, false
+	}
+
+	application, err := applications.FindByID(c.Request.Context(), uint(applicationID))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Application not found"})
+		return nil, false
+	}
+
+	if application.UserID != userID.(uint) && !canManageJob(c.Request.Context(), jobs, userID.(uint), role, &application.Job) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Not authorized to view messages for this application"})
+		return nil, false
+	}
+
+	return application, true
+}
+
+// GetMessages lists the conversation of an application, newest first.
+func (h *ApplicationHandler) GetMessages(c *gin.Context) {
+	userID, _ := c.Get("userID")
+	application, ok := loadApplicationThread(c, h.Applications, h.Jobs)
+	if !ok {
+		return
+	}
+
+	page, err := pagination.OffsetFromQuery(c.Request.URL.Query(), 50)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	messages, total, err := h.Applications.ListMessages(c.Request.Context(), application.ID, page.Offset, page.Limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch messages"})
+		return
+	}
+	unread, err := h.Applications.CountUnread(c.Request.Context(), application, userID.(uint))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch messages"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"messages": messages,
+		"total":    total,
+		"unread":   unread,
+		"page":     page.Number(),
+		"limit":    page.Limit,
+	})
+}
+
+// SendMessage posts a message to the conversation of an application.
+func (h *ApplicationHandler) SendMessage(c *gin.Context) {
+	userID, _ := c.Get("userID")
+	application, ok := loadApplicationThread(c, h.Applications, h.Jobs)
+	if !ok {
+		return
+	}
+
+	var req SendMessageRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	req.Body = strings.TrimSpace(req.Body)
+	if req.Body == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Message body is required"})
+		return
+	}
+
+	message := models.ApplicationMessage{
+		ApplicationID: application.ID,
+		SenderID:      userID.(uint),
+		Body:          req.Body,
+	}
+	for _, attachment := range req.Attachments {
+		message.Attachments = append(message.Attachments, models.MessageAttachment{
+			Name: attachment.Name,
+			URL:  attachment.URL,
+		})
+	}
+
+	if err := h.Applications.CreateMessage(c.Request.Context(), &message); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to send message"})
+		return
+	}
+	notifyApplicationMessage(c, h.Notifications, h.Jobs, application, &message)
+
+	c.JSON(http.StatusCreated, gin.H{"message": message})
+}
+
+// MarkMessagesRead marks every message from the other side as read.
+func (h *ApplicationHandler) MarkMessagesRead(c *gin.Context) {
+	userID, _ := c.Get("userID")
+	application, ok := loadApplicationThread(c, h.Applications, h.Jobs)
+	if !ok {
+		return
+	}
+
+	updated, err := h.Applications.MarkRead(c.Request.Context(), application, userID.(uint), time.Now())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update messages"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"updated": updated})
+}
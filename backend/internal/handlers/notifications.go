@@ -0,0 +1,170 @@
+package handlers
+
+import (
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"job-search-backend/internal/database"
+	"job-search-backend/internal/models"
+	"job-search-backend/internal/notifications"
+	"job-search-backend/internal/pagination"
+	"job-search-backend/internal/repository"
+
+	"github.com/gin-gonic/gin"
+)
+
+// streamHeartbeat keeps idle event streams from being closed by proxies.
+const streamHeartbeat = 25 * time.Second
+
+type NotificationHandler struct {
+	Service *notifications.Service
+	// Users re-checks the session of open streams on every heartbeat
+	Users repository.UserRepository
+}
+
+// GetNotifications lists the user's notifications, newest first. unread=true
+// returns only the unread ones.
+func (h *NotificationHandler) GetNotifications(c *gin.Context) {
+	userID, _ := c.Get("userID")
+
+	page, err := pagination.OffsetFromQuery(c.Request.URL.Query(), 20)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	query := database.DB.Model(&models.Notification{}).Where("user_id = ?", userID)
+	if c.Query("unread") == "true" {
+		query = query.Where("read_at IS NULL")
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch notifications"})
+		return
+	}
+
+	var items []models.Notification
+	if err := query.Order("created_at DESC, id DESC").Offset(page.Offset).Limit(page.Limit).Find(&items).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch notifications"})
+		return
+	}
+
+	unread, err := h.Service.UnreadCount(c.Request.Context(), userID.(uint))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch notifications"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"notifications": items,
+		"total":         total,
+		"unread":        unread,
+		"page":          page.Number(),
+		"limit":         page.Limit,
+	})
+}
+
+func (h *NotificationHandler) GetUnreadCount(c *gin.Context) {
+	userID, _ := c.Get("userID")
+
+	unread, err := h.Service.UnreadCount(c.Request.Context(), userID.(uint))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to count notifications"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"unread": unread})
+}
+
+func (h *NotificationHandler) MarkRead(c *gin.Context) {
+	userID, _ := c.Get("userID")
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid notification ID"})
+		return
+	}
+
+	var notification models.Notification
+	if err := database.DB.Where("id = ? AND user_id = ?", id, userID).First(&notification).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Notification not found"})
+		return
+	}
+
+	if _, err := h.Service.MarkRead(c.Request.Context(), userID.(uint), notification.ID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update notification"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Notification marked as read"})
+}
+
+func (h *NotificationHandler) MarkAllRead(c *gin.Context) {
+	userID, _ := c.Get("userID")
+
+	updated, err := h.Service.MarkRead(c.Request.Context(), userID.(uint))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update notifications"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"updated": updated})
+}
+
+// Stream is a server-sent events stream of the user's notifications. It
+// starts with an "unread" event carrying the unread count, then sends a
+// "notification" event for every new notification and an "unread" event
+// whenever the count changes.
+//
+// The access token is only checked when the stream opens, so the stream ends
+// when the token expires or, as seen on the next heartbeat, when its session
+// is logged out or revoked. The client then reconnects with a fresh token.
+func (h *NotificationHandler) Stream(c *gin.Context) {
+	userID, _ := c.Get("userID")
+	sessionID := c.GetString("sessionID")
+
+	unread, err := h.Service.UnreadCount(c.Request.Context(), userID.(uint))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to count notifications"})
+		return
+	}
+
+	events, unsubscribe := h.Service.Hub.Subscribe(userID.(uint))
+	defer unsubscribe()
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+	c.SSEvent(notifications.EventUnread, gin.H{"unread": unread})
+	c.Writer.Flush()
+
+	heartbeat := time.NewTicker(streamHeartbeat)
+	defer heartbeat.Stop()
+	expiry := time.NewTimer(time.Until(c.GetTime("tokenExpiresAt")))
+	defer expiry.Stop()
+
+	c.Stream(func(w io.Writer) bool {
+		select {
+		case <-c.Request.Context().Done():
+			return false
+		case event, ok := <-events:
+			if !ok {
+				return false
+			}
+			c.SSEvent(event.Name, event.Data)
+			return true
+		case <-expiry.C:
+			return false
+		case <-heartbeat.C:
+			active, err := h.Users.SessionActive(c.Request.Context(), userID.(uint), sessionID)
+			if err != nil || !active {
+				return false
+			}
+			_, err = io.WriteString(w, ": ping\n\n")
+			return err == nil
+		}
+	})
+}
@@ -0,0 +1,160 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	"job-search-backend/internal/models"
+	"job-search-backend/internal/notifications"
+	"job-search-backend/internal/repository"
+
+	"github.com/gin-gonic/gin"
+)
+
+// applicationStatusLabels are the status names shown to users, as in the frontend.
+var applicationStatusLabels = map[string]string{
+	models.ApplicationStatusApplied:   "На рассмотрении",
+	models.ApplicationStatusScreening: "Отбор",
+	models.ApplicationStatusInterview: "Собеседование",
+	models.ApplicationStatusOffer:     "Предложение",
+	models.ApplicationStatusHired:     "Принят на работу",
+	models.ApplicationStatusRejected:  "Отклонена",
+	models.ApplicationStatusWithdrawn: "Отозвана",
+}
+
+// sendNotifications delivers notifications about something that has already
+// happened. Failures are only logged so they never fail the request itself.
+func sendNotifications(c *gin.Context, service *notifications.Service, notes ...models.Notification) {
+	if service == nil || len(notes) == 0 {
+		return
+	}
+	if err := service.Send(c.Request.Context(), notes...); err != nil {
+		log.Println("Failed to send notifications:", err)
+	}
+}
+
+// jobManagerIDs returns the users who handle the job's applications: the
+// members of its company or, for jobs without a company, the employer who
+// posted it.
+func jobManagerIDs(ctx context.Context, jobs repository.JobRepository, job *models.Job) ([]uint, error) {
+	if job.CompanyID == nil {
+		return []uint{job.EmployerID}, nil
+	}
+	return jobs.CompanyMemberIDs(ctx, *job.CompanyID)
+}
+
+// notifyJobManagers sends the same notification to everyone handling the job
+// except the user who caused it.
+func notifyJobManagers(c *gin.Context, service *notifications.Service, jobs repository.JobRepository, job *models.Job, except uint, note models.Notification) {
+	if service == nil {
+		return
+	}
+	managers, err := jobManagerIDs(c.Request.Context(), jobs, job)
+	if err != nil {
+		log.Println("Failed to find job managers to notify:", err)
+		return
+	}
+	var notes []models.Notification
+	for _, id := range managers {
+		if id != except {
+			note.UserID = id
+			notes = append(notes, note)
+		}
+	}
+	sendNotifications(c, service, notes...)
+}
+
+// applicantName returns the name to show for the applicant in notifications.
+func applicantName(ctx context.Context, users repository.UserRepository, userID uint) string {
+	user, err := users.FindByID(ctx, userID)
+	if err != nil {
+		if !errors.Is(err, repository.ErrNotFound) {
+			log.Println("Failed to fetch applicant:", err)
+		}
+		return "кандидата"
+	}
+	return user.Name
+}
+
+func notifyApplicationReceived(c *gin.Context, service *notifications.Service, jobs repository.JobRepository, users repository.UserRepository, job *models.Job, application *models.JobApplication) {
+	if service == nil {
+		return
+	}
+	notifyJobManagers(c, service, jobs, job, application.UserID, models.Notification{
+		Type:  models.NotificationApplicationReceived,
+		Title: fmt.Sprintf("Новый отклик на вакансию «%s»", job.Title),
+		Body:  fmt.Sprintf("Отклик от %s", applicantName(c.Request.Context(), users, application.UserID)),
+		Link:  "/applications",
+	})
+}
+
+// notifyApplicationStatus tells the applicant that an employer moved their
+// application, or the employer side that the applicant withdrew it.
+func notifyApplicationStatus(c *gin.Context, service *notifications.Service, jobs repository.JobRepository, users repository.UserRepository, job *models.Job, application *models.JobApplication, changedBy uint, note string) {
+	if service == nil {
+		return
+	}
+	if application.Status == models.ApplicationStatusWithdrawn {
+		notifyJobManagers(c, service, jobs, job, changedBy, models.Notification{
+			Type:  models.NotificationApplicationWithdrawn,
+			Title: "Отклик отозван",
+			Body:  fmt.Sprintf("Отклик на вакансию «%s» от %s отозван", job.Title, applicantName(c.Request.Context(), users, application.UserID)),
+			Link:  "/applications",
+		})
+		return
+	}
+
+	if application.UserID == changedBy {
+		return
+	}
+	body := fmt.Sprintf("Вакансия «%s»: %s", job.Title, applicationStatusLabels[application.Status])
+	if note != "" {
+		body += "\n\n" + note
+	}
+	sendNotifications(c, service, models.Notification{
+		UserID: application.UserID,
+		Type:   models.NotificationApplicationStatus,
+		Title:  "Статус отклика изменён",
+		Body:   body,
+		Link:   "/applications",
+	})
+}
+
+// notifyApplicationMessage tells the other side of the conversation about a new message.
+func notifyApplicationMessage(c *gin.Context, service *notifications.Service, jobs repository.JobRepository, application *models.JobApplication, message *models.ApplicationMessage) {
+	note := models.Notification{
+		Type:  models.NotificationApplicationMessage,
+		Title: fmt.Sprintf("Новое сообщение по вакансии «%s»", application.Job.Title),
+		Body:  fmt.Sprintf("%s: %s", message.Sender.Name, truncate(message.Body, 200)),
+		Link:  "/applications",
+	}
+
+	if message.SenderID != application.UserID {
+		note.UserID = application.UserID
+		sendNotifications(c, service, note)
+		return
+	}
+	notifyJobManagers(c, service, jobs, &application.Job, message.SenderID, note)
+}
+
+// truncate shortens s to at most n runes, adding an ellipsis when cut.
+func truncate(s string, n int) string {
+	runes := []rune(s)
+	if len(runes) <= n {
+		return s
+	}
+	return string(runes[:n]) + "…"
+}
+
+// notifyJobClosed tells applicants whose applications are still open that
+// the job is no longer available.
+func notifyJobClosed(c *gin.Context, service *notifications.Service, job *models.Job) {
+	if service == nil {
+		return
+	}
+	if err := service.NotifyJobClosed(c.Request.Context(), job); err != nil {
+		log.Println("Failed to send job closed notifications:", err)
+	}
+}
@@ -0,0 +1,66 @@
+package handlers
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"job-search-backend/internal/models"
+	"job-search-backend/internal/repository/memory"
+)
+
+func TestJobManagers(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewStore()
+	company := &models.Company{Name: "Acme"}
+	store.AddCompany(company,
+		models.CompanyMember{UserID: 1, Role: models.CompanyRoleOwner},
+		models.CompanyMember{UserID: 2, Role: models.CompanyRoleRecruiter},
+	)
+
+	for _, tt := range []struct {
+		job  *models.Job
+		want []uint
+	}{
+		{&models.Job{EmployerID: 1, CompanyID: &company.ID}, []uint{1, 2}},
+		// The employer who posted it has since left the company
+		{&models.Job{EmployerID: 3, CompanyID: &company.ID}, []uint{1, 2}},
+		{&models.Job{EmployerID: 3}, []uint{3}},
+	} {
+		ids, err := jobManagerIDs(ctx, store.Jobs(), tt.job)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(ids, tt.want) {
+			t.Fatalf("job posted by %d: got %v, want %v", tt.job.EmployerID, ids, tt.want)
+		}
+	}
+
+	for _, tt := range []struct {
+		userID uint
+		roles  []string
+		want   bool
+	}{
+		{2, nil, true},
+		{2, []string{models.CompanyRoleOwner}, false},
+		{1, []string{models.CompanyRoleOwner}, true},
+		{3, nil, false},
+	} {
+		got, err := store.Jobs().IsCompanyMember(ctx, company.ID, tt.userID, tt.roles...)
+		if err != nil || got != tt.want {
+			t.Errorf("user %d with roles %v: got %v, %v; want %v", tt.userID, tt.roles, got, err, tt.want)
+		}
+	}
+
+	users := store.Users()
+	applicant := &models.User{Email: "dev@example.com", Name: "Dev"}
+	if err := users.Create(ctx, applicant); err != nil {
+		t.Fatal(err)
+	}
+	if got := applicantName(ctx, users, applicant.ID); got != "Dev" {
+		t.Errorf("applicant name: got %q", got)
+	}
+	if got := applicantName(ctx, users, 99); got != "кандидата" {
+		t.Errorf("missing applicant name: got %q", got)
+	}
+}
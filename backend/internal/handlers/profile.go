@@ -0,0 +1,180 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"job-search-backend/internal/database"
+	"job-search-backend/internal/models"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ProfileHandler struct{}
+
+// UpdateProfileRequest is used by both PUT and PATCH. With PUT every omitted
+// field is cleared, with PATCH omitted fields keep their current value.
+type UpdateProfileRequest struct {
+	Name       *string `json:"name" binding:"omitempty,max=100"`
+	Phone      *string `json:"phone" binding:"omitempty,max=32"`
+	Location   *string `json:"location" binding:"omitempty,max=255"`
+	Experience *string `json:"experience" binding:"omitempty,max=5000"`
+	Skills     *string `json:"skills" binding:"omitempty,max=2000"`
+	Education  *string `json:"education" binding:"omitempty,max=2000"`
+	Resume     *string `json:"resume" binding:"omitempty,max=10000"`
+
+	// Preferred work mode (onsite, remote, hybrid or empty) and structured
+	// location. Coordinates are geocoded from city or location when omitted.
+	WorkMode  *string  `json:"work_mode"`
+	City      *string  `json:"city" binding:"omitempty,max=100"`
+	Country   *string  `json:"country" binding:"omitempty,max=2"`
+	Latitude  *float64 `json:"latitude" binding:"omitempty,min=-90,max=90"`
+	Longitude *float64 `json:"longitude" binding:"omitempty,min=-180,max=180"`
+}
+
+var phonePattern = regexp.MustCompile(`^\+?[0-9\s\-()]{5,20}$`)
+
+func (h *ProfileHandler) GetProfile(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	var user models.User
+	if err := database.DB.Preload("UserProfile").First(&user, userID).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"user": user})
+}
+
+// ReplaceProfile handles PUT /api/profile.
+func (h *ProfileHandler) ReplaceProfile(c *gin.Context) {
+	h.saveProfile(c, false)
+}
+
+// PatchProfile handles PATCH /api/profile.
+func (h *ProfileHandler) PatchProfile(c *gin.Context) {
+	h.saveProfile(c, true)
+}
+
+func (h *ProfileHandler) saveProfile(c *gin.Context, partial bool) {
+	userID, _ := c.Get("userID")
+
+	var req UpdateProfileRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if req.Name != nil && strings.TrimSpace(*req.Name) == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Name cannot be empty"})
+		return
+	}
+	if req.Phone != nil && *req.Phone != "" && !phonePattern.MatchString(*req.Phone) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid phone number"})
+		return
+	}
+
+	if req.WorkMode != nil {
+		switch *req.WorkMode {
+		case "", models.WorkModeOnsite, models.WorkModeRemote, models.WorkModeHybrid:
+		default:
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid work_mode, expected onsite, remote or hybrid"})
+			return
+		}
+	}
+	if (req.Latitude == nil) != (req.Longitude == nil) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "latitude and longitude must be given together"})
+		return
+	}
+
+	var user models.User
+	if err := database.DB.First(&user, userID).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
+		return
+	}
+
+	// The first save of two requests at once inserts only one profile; the
+	// other request is then applied on top of it as an update.
+	var profile models.UserProfile
+	err := database.DB.Transaction(func(tx *gorm.DB) error {
+		if req.Name != nil {
+			user.Name = strings.TrimSpace(*req.Name)
+			if err := tx.Model(&user).Update("name", user.Name).Error; err != nil {
+				return err
+			}
+		}
+
+		for retried := false; ; retried = true {
+			profile = models.UserProfile{}
+			err := tx.Where("user_id = ?", user.ID).First(&profile).Error
+			if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+				return err
+			}
+			profile.UserID = user.ID
+			req.apply(&profile, partial)
+			if profile.ID != 0 {
+				return tx.Save(&profile).Error
+			}
+
+			result := tx.Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "user_id"}}, DoNothing: true}).Create(&profile)
+			if result.Error != nil || result.RowsAffected > 0 {
+				return result.Error
+			}
+			if retried {
+				return errors.New("profile insert conflicted again")
+			}
+		}
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save profile"})
+		return
+	}
+
+	user.UserProfile = &profile
+	c.JSON(http.StatusOK, gin.H{"user": user})
+}
+
+// apply sets the requested fields on the profile. With partial, omitted
+// fields keep their current value.
+func (r *UpdateProfileRequest) apply(profile *models.UserProfile, partial bool) {
+	assign := func(dst *string, src *string) {
+		if src != nil {
+			*dst = strings.TrimSpace(*src)
+		} else if !partial {
+			*dst = ""
+		}
+	}
+	assign(&profile.Phone, r.Phone)
+	assign(&profile.Location, r.Location)
+	assign(&profile.Experience, r.Experience)
+	assign(&profile.Skills, r.Skills)
+	assign(&profile.Education, r.Education)
+	assign(&profile.Resume, r.Resume)
+	assign(&profile.WorkMode, r.WorkMode)
+
+	// A new location without coordinates is geocoded again, so the parts
+	// derived from the old one are dropped
+	if r.Location != nil && r.City == nil {
+		profile.City = ""
+	}
+	if (r.Location != nil || r.City != nil) && r.Country == nil {
+		profile.Country = ""
+	}
+	assign(&profile.City, r.City)
+	assign(&profile.Country, r.Country)
+	profile.Country = strings.ToUpper(profile.Country)
+	if r.Latitude != nil {
+		profile.Latitude, profile.Longitude = r.Latitude, r.Longitude
+	} else if !partial || r.Location != nil || r.City != nil {
+		profile.Latitude, profile.Longitude = nil, nil
+		profile.Place.Geocode(profile.Location)
+	}
+}
@@ -0,0 +1,432 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"job-search-backend/internal/database"
+	"job-search-backend/internal/models"
+	"job-search-backend/internal/utils"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// ReferenceHandler serves the job categories and employment types. Reading
+// them is public; changing them is reserved for admins.
+type ReferenceHandler struct{}
+
+type CategoryRequest struct {
+	Slug     string `json:"slug" binding:"max=64"` // generated from name_en when empty
+	ParentID *uint  `json:"parent_id"`
+	NameRu   string `json:"name_ru" binding:"required,max=100"`
+	NameEn   string `json:"name_en" binding:"required,max=100"`
+	Position int    `json:"position"`
+}
+
+type EmploymentTypeRequest struct {
+	Slug     string `json:"slug" binding:"max=64"`
+	NameRu   string `json:"name_ru" binding:"required,max=100"`
+	NameEn   string `json:"name_en" binding:"required,max=100"`
+	Position int    `json:"position"`
+}
+
+var slugPattern = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+var (
+	errSlugTaken      = errors.New("slug is already taken")
+	errCategoryCycle  = errors.New("a category cannot be moved under itself or its subcategories")
+	errParentNotFound = errors.New("parent category not found")
+)
+
+// requestLang picks the display language from the lang query parameter or
+// the Accept-Language header. Russian is the default.
+func requestLang(c *gin.Context) string {
+	if lang := c.Query("lang"); lang == "ru" || lang == "en" {
+		return lang
+	}
+	if strings.HasPrefix(strings.ToLower(c.GetHeader("Accept-Language")), "en") {
+		return "en"
+	}
+	return "ru"
+}
+
+// referenceSlug returns the requested slug or one derived from the English
+// name, and checks its format.
+func referenceSlug(slug, nameEn string) (string, error) {
+	slug = strings.TrimSpace(slug)
+	if slug == "" {
+		slug = utils.Slugify(nameEn)
+	}
+	if !slugPattern.MatchString(slug) {
+		return "", errors.New("slug may only contain lowercase latin letters, digits and dashes")
+	}
+	return slug, nil
+}
+
+// ListCategories returns the category tree, or a flat list with ?flat=true.
+func (h *ReferenceHandler) ListCategories(c *gin.Context) {
+	var categories []models.Category
+	if err := database.DB.Order("position ASC, name_ru ASC").Find(&categories).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch categories"})
+		return
+	}
+
+	lang := requestLang(c)
+	if c.Query("flat") == "true" {
+		for i := range categories {
+			categories[i].Localize(lang)
+		}
+		c.JSON(http.StatusOK, gin.H{"categories": categories})
+		return
+	}
+
+	tree := categoryTree(categories, nil)
+	for i := range tree {
+		tree[i].Localize(lang)
+	}
+	c.JSON(http.StatusOK, gin.H{"categories": tree})
+}
+
+// categoryTree returns the categories under parentID with their subtrees,
+// keeping the order of the flat list.
+func categoryTree(categories []models.Category, parentID *uint) []models.Category {
+	nodes := []models.Category{}
+	for _, category := range categories {
+		if (category.ParentID == nil) != (parentID == nil) || (parentID != nil && *category.ParentID != *parentID) {
+			continue
+		}
+		category.Children = categoryTree(categories, &category.ID)
+		nodes = append(nodes, category)
+	}
+	return nodes
+}
+
+func (h *ReferenceHandler) CreateCategory(c *gin.Context) {
+	var req CategoryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	slug, err := referenceSlug(req.Slug, req.NameEn)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	category := models.Category{
+		Slug:     slug,
+		ParentID: req.ParentID,
+		NameRu:   strings.TrimSpace(req.NameRu),
+		NameEn:   strings.TrimSpace(req.NameEn),
+		Position: req.Position,
+	}
+	err = database.DB.Transaction(func(tx *gorm.DB) error {
+		if err := checkSlugFree(tx, &models.Category{}, slug, 0); err != nil {
+			return err
+		}
+		if err := checkCategoryParent(tx, 0, req.ParentID); err != nil {
+			return err
+		}
+		return tx.Create(&category).Error
+	})
+	if !respondReferenceError(c, err, "Failed to create category") {
+		return
+	}
+
+	category.Localize(requestLang(c))
+	c.JSON(http.StatusCreated, gin.H{"category": category})
+}
+
+// UpdateCategory changes a category. Renaming the slug also updates the jobs
+// and saved searches that use it.
+func (h *ReferenceHandler) UpdateCategory(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid category ID"})
+		return
+	}
+
+	var category models.Category
+	if err := database.DB.First(&category, id).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Category not found"})
+		return
+	}
+
+	var req CategoryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if req.Slug == "" {
+		req.Slug = category.Slug
+	}
+	slug, err := referenceSlug(req.Slug, req.NameEn)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	oldSlug := category.Slug
+	category.Slug = slug
+	category.ParentID = req.ParentID
+	category.NameRu = strings.TrimSpace(req.NameRu)
+	category.NameEn = strings.TrimSpace(req.NameEn)
+	category.Position = req.Position
+
+	err = database.DB.Transaction(func(tx *gorm.DB) error {
+		if err := checkSlugFree(tx, &models.Category{}, slug, category.ID); err != nil {
+			return err
+		}
+		if err := checkCategoryParent(tx, category.ID, req.ParentID); err != nil {
+			return err
+		}
+		if err := tx.Omit("Children").Save(&category).Error; err != nil {
+			return err
+		}
+		return renameReferenceSlug(tx, "category", oldSlug, slug)
+	})
+	if !respondReferenceError(c, err, "Failed to update category") {
+		return
+	}
+
+	category.Localize(requestLang(c))
+	c.JSON(http.StatusOK, gin.H{"category": category})
+}
+
+// DeleteCategory removes a category that has no subcategories and no jobs.
+func (h *ReferenceHandler) DeleteCategory(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid category ID"})
+		return
+	}
+
+	var category models.Category
+	if err := database.DB.First(&category, id).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Category not found"})
+		return
+	}
+
+	var children int64
+	database.DB.Model(&models.Category{}).Where("parent_id = ?", category.ID).Count(&children)
+	if children > 0 {
+		c.JSON(http.StatusConflict, gin.H{"error": "Category has subcategories"})
+		return
+	}
+	var jobs int64
+	database.DB.Model(&models.Job{}).Where("category = ?", category.Slug).Count(&jobs)
+	if jobs > 0 {
+		c.JSON(http.StatusConflict, gin.H{"error": "Category is used by jobs", "jobs": jobs})
+		return
+	}
+
+	if err := database.DB.Delete(&category).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete category"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Category deleted successfully"})
+}
+
+func (h *ReferenceHandler) ListEmploymentTypes(c *gin.Context) {
+	var types []models.EmploymentType
+	if err := database.DB.Order("position ASC, name_ru ASC").Find(&types).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch employment types"})
+		return
+	}
+
+	lang := requestLang(c)
+	for i := range types {
+		types[i].Localize(lang)
+	}
+	c.JSON(http.StatusOK, gin.H{"employment_types": types})
+}
+
+func (h *ReferenceHandler) CreateEmploymentType(c *gin.Context) {
+	var req EmploymentTypeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	slug, err := referenceSlug(req.Slug, req.NameEn)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	employmentType := models.EmploymentType{
+		Slug:     slug,
+		NameRu:   strings.TrimSpace(req.NameRu),
+		NameEn:   strings.TrimSpace(req.NameEn),
+		Position: req.Position,
+	}
+	err = database.DB.Transaction(func(tx *gorm.DB) error {
+		if err := checkSlugFree(tx, &models.EmploymentType{}, slug, 0); err != nil {
+			return err
+		}
+		return tx.Create(&employmentType).Error
+	})
+	if !respondReferenceError(c, err, "Failed to create employment type") {
+		return
+	}
+
+	employmentType.Localize(requestLang(c))
+	c.JSON(http.StatusCreated, gin.H{"employment_type": employmentType})
+}
+
+// UpdateEmploymentType changes an employment type. Renaming the slug also
+// updates the jobs and saved searches that use it.
+func (h *ReferenceHandler) UpdateEmploymentType(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid employment type ID"})
+		return
+	}
+
+	var employmentType models.EmploymentType
+	if err := database.DB.First(&employmentType, id).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Employment type not found"})
+		return
+	}
+
+	var req EmploymentTypeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if req.Slug == "" {
+		req.Slug = employmentType.Slug
+	}
+	slug, err := referenceSlug(req.Slug, req.NameEn)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	oldSlug := employmentType.Slug
+	employmentType.Slug = slug
+	employmentType.NameRu = strings.TrimSpace(req.NameRu)
+	employmentType.NameEn = strings.TrimSpace(req.NameEn)
+	employmentType.Position = req.Position
+
+	err = database.DB.Transaction(func(tx *gorm.DB) error {
+		if err := checkSlugFree(tx, &models.EmploymentType{}, slug, employmentType.ID); err != nil {
+			return err
+		}
+		if err := tx.Save(&employmentType).Error; err != nil {
+			return err
+		}
+		return renameReferenceSlug(tx, "type", oldSlug, slug)
+	})
+	if !respondReferenceError(c, err, "Failed to update employment type") {
+		return
+	}
+
+	employmentType.Localize(requestLang(c))
+	c.JSON(http.StatusOK, gin.H{"employment_type": employmentType})
+}
+
+// DeleteEmploymentType removes an employment type that no job uses.
+func (h *ReferenceHandler) DeleteEmploymentType(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid employment type ID"})
+		return
+	}
+
+	var employmentType models.EmploymentType
+	if err := database.DB.First(&employmentType, id).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Employment type not found"})
+		return
+	}
+
+	var jobs int64
+	database.DB.Model(&models.Job{}).Where("type = ?", employmentType.Slug).Count(&jobs)
+	if jobs > 0 {
+		c.JSON(http.StatusConflict, gin.H{"error": "Employment type is used by jobs", "jobs": jobs})
+		return
+	}
+
+	if err := database.DB.Delete(&employmentType).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete employment type"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Employment type deleted successfully"})
+}
+
+func checkSlugFree(tx *gorm.DB, model interface{}, slug string, exceptID uint) error {
+	var count int64
+	if err := tx.Model(model).Where("slug = ? AND id <> ?", slug, exceptID).Count(&count).Error; err != nil {
+		return err
+	}
+	if count > 0 {
+		return errSlugTaken
+	}
+	return nil
+}
+
+// checkCategoryParent checks that parentID exists and is neither the
+// category itself nor one of its descendants.
+func checkCategoryParent(tx *gorm.DB, categoryID uint, parentID *uint) error {
+	if parentID == nil {
+		return nil
+	}
+	var count int64
+	if err := tx.Model(&models.Category{}).Where("id = ?", *parentID).Count(&count).Error; err != nil {
+		return err
+	}
+	if count == 0 {
+		return errParentNotFound
+	}
+	if categoryID == 0 {
+		return nil
+	}
+
+	if err := tx.Raw(`WITH RECURSIVE tree AS (
+			SELECT id FROM categories WHERE id = ?
+			UNION ALL
+			SELECT c.id FROM categories c JOIN tree ON c.parent_id = tree.id
+		)
+		SELECT COUNT(*) FROM tree WHERE id = ?`, categoryID, *parentID).Scan(&count).Error; err != nil {
+		return err
+	}
+	if count > 0 {
+		return errCategoryCycle
+	}
+	return nil
+}
+
+// renameReferenceSlug moves the jobs and saved searches from one category or
+// type slug to another; column is "category" or "type".
+func renameReferenceSlug(tx *gorm.DB, column, oldSlug, newSlug string) error {
+	if oldSlug == newSlug {
+		return nil
+	}
+	if err := tx.Unscoped().Model(&models.Job{}).Where(column+" = ?", oldSlug).Update(column, newSlug).Error; err != nil {
+		return err
+	}
+	return tx.Model(&models.SavedSearch{}).Where("filter_"+column+" = ?", oldSlug).Update("filter_"+column, newSlug).Error
+}
+
+// respondReferenceError writes the response for an error of a reference data
+// change and reports whether the request succeeded.
+func respondReferenceError(c *gin.Context, err error, message string) bool {
+	switch {
+	case err == nil:
+		return true
+	case errors.Is(err, errSlugTaken):
+		c.JSON(http.StatusConflict, gin.H{"error": "Slug is already taken"})
+	case errors.Is(err, errParentNotFound):
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Parent category not found"})
+	case errors.Is(err, errCategoryCycle):
+		c.JSON(http.StatusBadRequest, gin.H{"error": "A category cannot be moved under itself or its subcategories"})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": message})
+	}
+	return false
+}
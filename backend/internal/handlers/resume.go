@@ -0,0 +1,242 @@
+package handlers
+
+import (
+	"archive/zip"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"job-search-backend/internal/database"
+	"job-search-backend/internal/models"
+	"job-search-backend/internal/storage"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+const (
+	DefaultMaxResumeSize = 5 << 20 // 5 MB
+
+	mimePDF  = "application/pdf"
+	mimeDOCX = "application/vnd.openxmlformats-officedocument.wordprocessingml.document"
+)
+
+type ResumeHandler struct {
+	Storage storage.Storage
+	MaxSize int64
+}
+
+func (h *ResumeHandler) maxSize() int64 {
+	if h.MaxSize > 0 {
+		return h.MaxSize
+	}
+	return DefaultMaxResumeSize
+}
+
+// UploadResume accepts a multipart form with a "file" field containing a PDF or DOCX document.
+func (h *ResumeHandler) UploadResume(c *gin.Context) {
+	userID, _ := c.Get("userID")
+
+	// Leave some room for the multipart envelope around the file itself.
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, h.maxSize()+1<<20)
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "File is required"})
+		return
+	}
+	if fileHeader.Size > h.maxSize() {
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": fmt.Sprintf("File is too large (max %d bytes)", h.maxSize())})
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Failed to read file"})
+		return
+	}
+	defer file.Close()
+
+	contentType, err := detectResumeType(file, fileHeader)
+	if err != nil {
+		c.JSON(http.StatusUnsupportedMediaType, gin.H{"error": err.Error()})
+		return
+	}
+
+	var profile models.UserProfile
+	err = database.DB.Where("user_id = ?", userID).First(&profile).Error
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch profile"})
+		return
+	}
+	profile.UserID = userID.(uint)
+
+	ext := strings.ToLower(filepath.Ext(fileHeader.Filename))
+	key := fmt.Sprintf("resumes/%d/%d%s", profile.UserID, time.Now().UnixNano(), ext)
+	if err := h.Storage.Put(c.Request.Context(), key, file, fileHeader.Size, contentType); err != nil {
+		log.Println("Failed to store resume:", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to store file"})
+		return
+	}
+
+	oldKey := profile.ResumeKey
+	now := time.Now()
+	profile.ResumeKey = key
+	profile.ResumeFileName = filepath.Base(fileHeader.Filename)
+	profile.ResumeContentType = contentType
+	profile.ResumeSize = fileHeader.Size
+	profile.ResumeUploadedAt = &now
+
+	if err := database.DB.Save(&profile).Error; err != nil {
+		h.Storage.Delete(c.Request.Context(), key)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save profile"})
+		return
+	}
+
+	if oldKey != "" {
+		if err := h.Storage.Delete(c.Request.Context(), oldKey); err != nil {
+			log.Println("Failed to delete previous resume:", err)
+		}
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"profile": profile})
+}
+
+func (h *ResumeHandler) DeleteResume(c *gin.Context) {
+	userID, _ := c.Get("userID")
+
+	var profile models.UserProfile
+	if err := database.DB.Where("user_id = ?", userID).First(&profile).Error; err != nil || profile.ResumeKey == "" {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Resume not found"})
+		return
+	}
+
+	key := profile.ResumeKey
+	if err := database.DB.Model(&profile).Updates(map[string]interface{}{
+		"resume_key":          "",
+		"resume_file_name":    "",
+		"resume_content_type": "",
+		"resume_size":         0,
+		"resume_uploaded_at":  nil,
+	}).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete resume"})
+		return
+	}
+
+	if err := h.Storage.Delete(c.Request.Context(), key); err != nil {
+		log.Println("Failed to delete resume file:", err)
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Resume deleted successfully"})
+}
+
+// DownloadOwnResume serves the authenticated user's resume.
+func (h *ResumeHandler) DownloadOwnResume(c *gin.Context) {
+	userID, _ := c.Get("userID")
+	h.serveResume(c, userID.(uint))
+}
+
+// DownloadResume serves the resume of the given user. Only the owner, an admin
+// or an employer (or company recruiter) with an application from that user to
+// one of their jobs may fetch it.
+func (h *ResumeHandler) DownloadResume(c *gin.Context) {
+	userID, _ := c.Get("userID")
+	role, _ := c.Get("role")
+	ownerID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+
+	if uint(ownerID) != userID.(uint) && role != "admin" {
+		var count int64
+		if err := database.DB.Model(&models.JobApplication{}).
+			Joins("JOIN jobs ON job_applications.job_id = jobs.id").
+			Where("job_applications.user_id = ?", ownerID).
+			Scopes(managedJobsScope(userID.(uint))).
+			Count(&count).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check access"})
+			return
+		}
+		if count == 0 {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Not authorized to view this resume"})
+			return
+		}
+	}
+
+	h.serveResume(c, uint(ownerID))
+}
+
+func (h *ResumeHandler) serveResume(c *gin.Context, ownerID uint) {
+	var profile models.UserProfile
+	if err := database.DB.Where("user_id = ?", ownerID).First(&profile).Error; err != nil || profile.ResumeKey == "" {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Resume not found"})
+		return
+	}
+
+	reader, err := h.Storage.Get(c.Request.Context(), profile.ResumeKey)
+	if errors.Is(err, storage.ErrNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Resume not found"})
+		return
+	}
+	if err != nil {
+		log.Println("Failed to read resume:", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to read file"})
+		return
+	}
+	defer reader.Close()
+
+	disposition := mime.FormatMediaType("attachment", map[string]string{"filename": profile.ResumeFileName})
+	c.DataFromReader(http.StatusOK, profile.ResumeSize, profile.ResumeContentType, reader, map[string]string{
+		"Content-Disposition":    disposition,
+		"X-Content-Type-Options": "nosniff",
+	})
+}
+
+// detectResumeType checks the file contents rather than trusting the client's
+// Content-Type header. It rewinds the file before returning.
+func detectResumeType(file multipart.File, header *multipart.FileHeader) (string, error) {
+	head := make([]byte, 512)
+	n, err := io.ReadFull(file, head)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return "", errors.New("Failed to read file")
+	}
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return "", errors.New("Failed to read file")
+	}
+
+	detected := http.DetectContentType(head[:n])
+	ext := strings.ToLower(filepath.Ext(header.Filename))
+
+	switch {
+	case ext == ".pdf" && detected == mimePDF:
+		return mimePDF, nil
+	case ext == ".docx" && detected == "application/zip" && isDOCX(file, header.Size):
+		if _, err := file.Seek(0, io.SeekStart); err != nil {
+			return "", errors.New("Failed to read file")
+		}
+		return mimeDOCX, nil
+	}
+	return "", errors.New("Only PDF and DOCX files are allowed")
+}
+
+func isDOCX(file multipart.File, size int64) bool {
+	archive, err := zip.NewReader(file, size)
+	if err != nil {
+		return false
+	}
+	for _, f := range archive.File {
+		if f.Name == "word/document.xml" {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,110 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"job-search-backend/internal/models"
+	"job-search-backend/internal/pagination"
+	"job-search-backend/internal/repository"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SaveJob bookmarks an active job for the current user. Saving a job twice is a no-op.
+func (h *JobHandler) SaveJob(c *gin.Context) {
+	userID, _ := c.Get("userID")
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid job ID"})
+		return
+	}
+
+	job, err := h.Jobs.FindByID(c.Request.Context(), uint(id))
+	if err != nil || !job.IsActive {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Job not found"})
+		return
+	}
+
+	if err := h.Jobs.SaveJob(c.Request.Context(), userID.(uint), job.ID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save job"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Job saved successfully"})
+}
+
+// UnsaveJob removes a bookmark. Removing a job that is not saved is a no-op.
+func (h *JobHandler) UnsaveJob(c *gin.Context) {
+	userID, _ := c.Get("userID")
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid job ID"})
+		return
+	}
+
+	if err := h.Jobs.UnsaveJob(c.Request.Context(), userID.(uint), uint(id)); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to remove saved job"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Job removed from saved"})
+}
+
+// GetSavedJobs lists the current user's bookmarks, most recently saved first.
+// Jobs that have since been deleted are skipped; closed ones are kept so the
+// user can see they are no longer open.
+func (h *JobHandler) GetSavedJobs(c *gin.Context) {
+	userID, _ := c.Get("userID")
+
+	page, err := pagination.OffsetFromQuery(c.Request.URL.Query(), 10)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	saved, total, err := h.Jobs.ListSaved(c.Request.Context(), userID.(uint), page.Offset, page.Limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch saved jobs"})
+		return
+	}
+
+	isSaved := true
+	for i := range saved {
+		saved[i].Job.IsSaved = &isSaved
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"saved_jobs": saved,
+		"total":      total,
+		"page":       page.Number(),
+		"limit":      page.Limit,
+	})
+}
+
+// markSavedJobs sets IsSaved on the jobs when the request is authenticated.
+func markSavedJobs(c *gin.Context, repo repository.JobRepository, jobs []models.Job) {
+	userID, ok := c.Get("userID")
+	if !ok || len(jobs) == 0 {
+		return
+	}
+
+	ids := make([]uint, len(jobs))
+	for i := range jobs {
+		ids[i] = jobs[i].ID
+	}
+
+	savedIDs, err := repo.SavedJobIDs(c.Request.Context(), userID.(uint), ids)
+	if err != nil {
+		return
+	}
+
+	saved := make(map[uint]bool, len(savedIDs))
+	for _, id := range savedIDs {
+		saved[id] = true
+	}
+	for i := range jobs {
+		isSaved := saved[jobs[i].ID]
+		jobs[i].IsSaved = &isSaved
+	}
+}
@@ -0,0 +1,159 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"job-search-backend/internal/database"
+	"job-search-backend/internal/jobfilter"
+	"job-search-backend/internal/models"
+	"job-search-backend/internal/repository"
+
+	"github.com/gin-gonic/gin"
+)
+
+type SavedSearchHandler struct {
+	// Jobs runs the saved searches
+	Jobs repository.JobRepository
+}
+
+type SavedSearchRequest struct {
+	Name        string           `json:"name" binding:"required,max=100"`
+	Filters     jobfilter.Filter `json:"filters"`
+	Frequency   string           `json:"frequency" binding:"omitempty,oneof=off hourly daily weekly"`
+	EmailAlerts *bool            `json:"email_alerts"`
+}
+
+// validate normalizes the request. It writes the error response itself and
+// returns false when the request is invalid.
+func (r *SavedSearchRequest) validate(c *gin.Context) bool {
+	if err := r.Filters.Normalize(); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return false
+	}
+	if r.Filters.IsEmpty() {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "At least one filter is required"})
+		return false
+	}
+	if r.Frequency == "" {
+		r.Frequency = models.AlertFrequencyDaily
+	}
+	return true
+}
+
+func (h *SavedSearchHandler) GetSavedSearches(c *gin.Context) {
+	userID, _ := c.Get("userID")
+
+	var searches []models.SavedSearch
+	if err := database.DB.Where("user_id = ?", userID).Order("created_at DESC").Find(&searches).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch saved searches"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"saved_searches": searches})
+}
+
+// CreateSavedSearch stores a search. Alerts cover jobs created from now on.
+func (h *SavedSearchHandler) CreateSavedSearch(c *gin.Context) {
+	userID, _ := c.Get("userID")
+
+	var req SavedSearchRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if !req.validate(c) {
+		return
+	}
+
+	search := models.SavedSearch{
+		UserID:          userID.(uint),
+		Name:            req.Name,
+		Filter:          req.Filters,
+		Frequency:       req.Frequency,
+		EmailAlerts:     req.EmailAlerts == nil || *req.EmailAlerts,
+		AlertsCheckedAt: time.Now(),
+	}
+	if err := database.DB.Create(&search).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save search"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"saved_search": search})
+}
+
+func (h *SavedSearchHandler) UpdateSavedSearch(c *gin.Context) {
+	search, ok := loadOwnSavedSearch(c)
+	if !ok {
+		return
+	}
+
+	var req SavedSearchRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if !req.validate(c) {
+		return
+	}
+
+	// Turning alerts back on must not send everything posted while they were off
+	if search.Frequency == models.AlertFrequencyOff && req.Frequency != models.AlertFrequencyOff {
+		search.AlertsCheckedAt = time.Now()
+	}
+	search.Name = req.Name
+	search.Filter = req.Filters
+	search.Frequency = req.Frequency
+	if req.EmailAlerts != nil {
+		search.EmailAlerts = *req.EmailAlerts
+	}
+
+	if err := database.DB.Save(search).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update saved search"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"saved_search": search})
+}
+
+func (h *SavedSearchHandler) DeleteSavedSearch(c *gin.Context) {
+	search, ok := loadOwnSavedSearch(c)
+	if !ok {
+		return
+	}
+
+	if err := database.DB.Delete(search).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete saved search"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Saved search deleted successfully"})
+}
+
+// RunSavedSearch lists the jobs currently matching a saved search. It accepts
+// the same page, limit and sort parameters as GET /api/jobs.
+func (h *SavedSearchHandler) RunSavedSearch(c *gin.Context) {
+	search, ok := loadOwnSavedSearch(c)
+	if !ok {
+		return
+	}
+
+	listJobs(c, h.Jobs, search.Filter)
+}
+
+func loadOwnSavedSearch(c *gin.Context) (*models.SavedSearch, bool) {
+	userID, _ := c.Get("userID")
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid saved search ID"})
+		return nil, false
+	}
+
+	var search models.SavedSearch
+	if err := database.DB.Where("id = ? AND user_id = ?", id, userID).First(&search).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Saved search not found"})
+		return nil, false
+	}
+	return &search, true
+}
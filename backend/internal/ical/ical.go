@@ -0,0 +1,152 @@
+// Package ical writes iCalendar (RFC 5545) invitations.
+package ical
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Calendar methods (RFC 5546).
+const (
+	MethodPublish = "PUBLISH"
+	MethodRequest = "REQUEST"
+	MethodCancel  = "CANCEL"
+)
+
+// Event statuses.
+const (
+	StatusTentative = "TENTATIVE"
+	StatusConfirmed = "CONFIRMED"
+	StatusCancelled = "CANCELLED"
+)
+
+const prodID = "-//Job Search//Interviews//RU"
+
+// Person is an organizer or attendee of an event.
+type Person struct {
+	Name  string
+	Email string
+}
+
+// Event is a single calendar event.
+type Event struct {
+	UID         string // stable across updates of the same event
+	Sequence    int    // revision number, must grow with every update
+	Start       time.Time
+	End         time.Time
+	Summary     string
+	Description string
+	Location    string
+	URL         string
+	Status      string
+	Organizer   *Person
+	Attendees   []Person
+	Updated     time.Time // DTSTAMP; defaults to now
+}
+
+// Write writes a calendar with the given method and events to w.
+func Write(w io.Writer, method string, events ...Event) error {
+	lw := &lineWriter{w: bufio.NewWriter(w)}
+
+	lw.line("BEGIN:VCALENDAR")
+	lw.line("VERSION:2.0")
+	lw.line("PRODID:" + prodID)
+	lw.line("CALSCALE:GREGORIAN")
+	if method != "" {
+		lw.line("METHOD:" + method)
+	}
+
+	for _, event := range events {
+		stamp := event.Updated
+		if stamp.IsZero() {
+			stamp = time.Now()
+		}
+
+		lw.line("BEGIN:VEVENT")
+		lw.line("UID:" + escape(event.UID))
+		lw.line("SEQUENCE:" + fmt.Sprint(event.Sequence))
+		lw.line("DTSTAMP:" + formatTime(stamp))
+		lw.line("DTSTART:" + formatTime(event.Start))
+		lw.line("DTEND:" + formatTime(event.End))
+		lw.line("SUMMARY:" + escape(event.Summary))
+		if event.Description != "" {
+			lw.line("DESCRIPTION:" + escape(event.Description))
+		}
+		if event.Location != "" {
+			lw.line("LOCATION:" + escape(event.Location))
+		}
+		if event.URL != "" {
+			lw.line("URL:" + event.URL)
+		}
+		if event.Status != "" {
+			lw.line("STATUS:" + event.Status)
+		}
+		if event.Organizer != nil {
+			lw.line("ORGANIZER" + personParams(*event.Organizer) + ":mailto:" + event.Organizer.Email)
+		}
+		for _, attendee := range event.Attendees {
+			lw.line("ATTENDEE;ROLE=REQ-PARTICIPANT" + personParams(attendee) + ":mailto:" + attendee.Email)
+		}
+		lw.line("END:VEVENT")
+	}
+
+	lw.line("END:VCALENDAR")
+	if lw.err != nil {
+		return lw.err
+	}
+	return lw.w.Flush()
+}
+
+func formatTime(t time.Time) string {
+	return t.UTC().Format("20060102T150405Z")
+}
+
+func personParams(p Person) string {
+	if p.Name == "" {
+		return ""
+	}
+	// Parameter values cannot contain quotes; quoting allows ",", ";" and ":"
+	return `;CN="` + strings.ReplaceAll(p.Name, `"`, "'") + `"`
+}
+
+// escape escapes a TEXT property value.
+func escape(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, ";", `\;`)
+	s = strings.ReplaceAll(s, ",", `\,`)
+	s = strings.ReplaceAll(s, "\r\n", `\n`)
+	s = strings.ReplaceAll(s, "\n", `\n`)
+	return s
+}
+
+// lineWriter writes content lines folded at 75 octets and terminated by CRLF.
+type lineWriter struct {
+	w   *bufio.Writer
+	err error
+}
+
+func (lw *lineWriter) line(s string) {
+	if lw.err != nil {
+		return
+	}
+
+	const limit = 75
+	width := limit
+	for len(s) > width {
+		// Never split a multi-byte character
+		cut := width
+		for cut > 0 && !utf8.RuneStart(s[cut]) {
+			cut--
+		}
+		if _, lw.err = lw.w.WriteString(s[:cut] + "\r\n "); lw.err != nil {
+			return
+		}
+		s = s[cut:]
+		width = limit - 1 // continuation lines start with a space
+	}
+	_, lw.err = lw.w.WriteString(s + "\r\n")
+}
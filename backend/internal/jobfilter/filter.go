@@ -0,0 +1,275 @@
+// Package jobfilter holds the job listing filters shared by the job search
+// endpoint, saved searches and the new-match alerts worker.
+package jobfilter
+
+import (
+	"errors"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"job-search-backend/internal/geo"
+	"job-search-backend/internal/utils"
+
+	"gorm.io/gorm"
+)
+
+// Filter is a set of job listing criteria. Empty fields do not filter.
+type Filter struct {
+	Search    string `json:"search,omitempty"`
+	Lang      string `json:"lang,omitempty"` // ru, en or empty for both
+	Category  string `json:"category,omitempty"`
+	Location  string `json:"location,omitempty"`
+	Type      string `json:"type,omitempty"`
+	SalaryMin *int   `json:"salary_min,omitempty"`
+	SalaryMax *int   `json:"salary_max,omitempty"`
+	Currency  string `json:"currency,omitempty" gorm:"size:3"`
+	// SalaryPeriod is hour, month or year; salaries are only compared
+	// within the same currency and period
+	SalaryPeriod string `json:"salary_period,omitempty"`
+
+	WorkMode string `json:"work_mode,omitempty"` // onsite, remote or hybrid
+	Country  string `json:"country,omitempty" gorm:"size:2"`
+
+	// Radius search around a point; Near is a city name that Normalize
+	// geocodes into Latitude/Longitude.
+	Near      string   `json:"near,omitempty"`
+	Latitude  *float64 `json:"latitude,omitempty"`
+	Longitude *float64 `json:"longitude,omitempty"`
+	RadiusKm  *float64 `json:"radius_km,omitempty"`
+}
+
+const (
+	DefaultRadiusKm = 50
+	MaxRadiusKm     = 1000
+)
+
+// FromQuery reads the filter from GET /api/jobs query parameters.
+func FromQuery(values url.Values) (Filter, error) {
+	f := Filter{
+		Search:       values.Get("search"),
+		Lang:         values.Get("lang"),
+		Category:     values.Get("category"),
+		Location:     values.Get("location"),
+		Type:         values.Get("type"),
+		Currency:     values.Get("currency"),
+		SalaryPeriod: values.Get("salary_period"),
+		WorkMode:     values.Get("work_mode"),
+		Country:      values.Get("country"),
+		Near:         values.Get("near"),
+	}
+
+	// remote=true is a shortcut for work_mode=remote
+	if remote := values.Get("remote"); remote != "" {
+		on, err := strconv.ParseBool(remote)
+		if err != nil {
+			return f, errors.New("Invalid remote, expected true or false")
+		}
+		if on {
+			if f.WorkMode != "" && f.WorkMode != "remote" {
+				return f, errors.New("remote=true conflicts with work_mode")
+			}
+			f.WorkMode = "remote"
+		}
+	}
+
+	for name, dst := range map[string]**float64{"lat": &f.Latitude, "lng": &f.Longitude, "radius_km": &f.RadiusKm} {
+		if raw := values.Get(name); raw != "" {
+			value, err := strconv.ParseFloat(raw, 64)
+			if err != nil {
+				return f, errors.New("Invalid " + name)
+			}
+			*dst = &value
+		}
+	}
+
+	if salaryMin := values.Get("salary_min"); salaryMin != "" {
+		value, err := strconv.Atoi(salaryMin)
+		if err != nil {
+			return f, errors.New("Invalid salary_min")
+		}
+		f.SalaryMin = &value
+	}
+	if salaryMax := values.Get("salary_max"); salaryMax != "" {
+		value, err := strconv.Atoi(salaryMax)
+		if err != nil {
+			return f, errors.New("Invalid salary_max")
+		}
+		f.SalaryMax = &value
+	}
+
+	return f, f.Normalize()
+}
+
+// Normalize trims the text fields and validates the filter.
+func (f *Filter) Normalize() error {
+	f.Search = strings.TrimSpace(f.Search)
+	f.Category = strings.TrimSpace(f.Category)
+	f.Location = strings.TrimSpace(f.Location)
+	f.Type = strings.TrimSpace(f.Type)
+	f.Currency = strings.ToUpper(strings.TrimSpace(f.Currency))
+	f.SalaryPeriod = strings.ToLower(strings.TrimSpace(f.SalaryPeriod))
+
+	if f.Lang != "" && f.Lang != "ru" && f.Lang != "en" {
+		return errors.New("Invalid lang, expected ru or en")
+	}
+	if f.SalaryMin != nil && *f.SalaryMin < 0 {
+		return errors.New("Invalid salary_min")
+	}
+	if f.SalaryMax != nil && *f.SalaryMax < 0 {
+		return errors.New("Invalid salary_max")
+	}
+	if f.SalaryPeriod != "" && f.SalaryPeriod != utils.SalaryPeriodHour &&
+		f.SalaryPeriod != utils.SalaryPeriodMonth && f.SalaryPeriod != utils.SalaryPeriodYear {
+		return errors.New("Invalid salary_period, expected hour, month or year")
+	}
+	if f.SalaryMin != nil || f.SalaryMax != nil {
+		f.DefaultSalaryUnit()
+	}
+	return f.normalizeLocation()
+}
+
+// DefaultSalaryUnit restricts the filter to one currency and period, monthly
+// roubles unless given. Amounts in different currencies or per different
+// periods are not comparable, so salary bounds and salary sorts need it.
+func (f *Filter) DefaultSalaryUnit() {
+	if f.Currency == "" {
+		f.Currency = utils.DefaultCurrency
+	}
+	if f.SalaryPeriod == "" {
+		f.SalaryPeriod = utils.SalaryPeriodMonth
+	}
+}
+
+// normalizeLocation validates the work mode, country and radius search.
+func (f *Filter) normalizeLocation() error {
+	f.WorkMode = strings.ToLower(strings.TrimSpace(f.WorkMode))
+	f.Country = strings.ToUpper(strings.TrimSpace(f.Country))
+	f.Near = strings.TrimSpace(f.Near)
+
+	if f.WorkMode != "" && f.WorkMode != "onsite" && f.WorkMode != "remote" && f.WorkMode != "hybrid" {
+		return errors.New("Invalid work_mode, expected onsite, remote or hybrid")
+	}
+	if f.Country != "" && len(f.Country) != 2 {
+		return errors.New("Invalid country, expected a two-letter ISO code")
+	}
+
+	if f.Near != "" && (f.Latitude == nil || f.Longitude == nil) {
+		city, ok := geo.Lookup(f.Near)
+		if !ok {
+			return errors.New("Unknown city in near, pass lat and lng instead")
+		}
+		f.Latitude, f.Longitude = &city.Latitude, &city.Longitude
+	}
+	if (f.Latitude == nil) != (f.Longitude == nil) {
+		return errors.New("lat and lng must be given together")
+	}
+	if !f.HasPoint() {
+		if f.RadiusKm != nil {
+			return errors.New("radius_km requires near or lat and lng")
+		}
+		return nil
+	}
+	if !geo.ValidCoordinates(*f.Latitude, *f.Longitude) {
+		return errors.New("Invalid coordinates")
+	}
+	if f.RadiusKm == nil {
+		radius := float64(DefaultRadiusKm)
+		f.RadiusKm = &radius
+	}
+	if *f.RadiusKm <= 0 || *f.RadiusKm > MaxRadiusKm {
+		return errors.New("Invalid radius_km, expected up to 1000")
+	}
+	return nil
+}
+
+// HasPoint reports whether the filter is a radius search.
+func (f Filter) HasPoint() bool {
+	return f.Latitude != nil && f.Longitude != nil
+}
+
+// DistanceExpr returns the SQL expression for the great-circle distance in
+// kilometres between a job and the filter's point, and its arguments. Jobs
+// without coordinates have a NULL distance.
+func (f Filter) DistanceExpr() (string, []interface{}) {
+	return "(6371 * acos(LEAST(1.0, cos(radians(?)) * cos(radians(jobs.latitude)) * cos(radians(jobs.longitude) - radians(?)) + sin(radians(?)) * sin(radians(jobs.latitude)))))",
+		[]interface{}{*f.Latitude, *f.Longitude, *f.Latitude}
+}
+
+// IsEmpty reports whether the filter matches every job.
+func (f Filter) IsEmpty() bool {
+	return f == Filter{Lang: f.Lang}
+}
+
+// SearchQuery returns the tsquery expression for the search text and its
+// arguments. By default the text is stemmed with both the Russian and English
+// configurations so that either language matches; Lang restricts it to one.
+func (f Filter) SearchQuery() (string, []interface{}) {
+	switch f.Lang {
+	case "ru":
+		return "websearch_to_tsquery('russian', ?)", []interface{}{f.Search}
+	case "en":
+		return "websearch_to_tsquery('english', ?)", []interface{}{f.Search}
+	}
+	return "(websearch_to_tsquery('russian', ?) || websearch_to_tsquery('english', ?))", []interface{}{f.Search, f.Search}
+}
+
+// Apply adds the filter conditions to a query on jobs.
+func (f Filter) Apply(db *gorm.DB) *gorm.DB {
+	// A category also matches the jobs of all its subcategories
+	if f.Category != "" {
+		db = db.Where(`jobs.category IN (
+			WITH RECURSIVE tree AS (
+				SELECT id, slug FROM categories WHERE slug = ?
+				UNION ALL
+				SELECT c.id, c.slug FROM categories c JOIN tree ON c.parent_id = tree.id
+			)
+			SELECT slug FROM tree)`, f.Category)
+	}
+	if f.Location != "" {
+		db = db.Where("jobs.location ILIKE ?", "%"+f.Location+"%")
+	}
+	if f.Type != "" {
+		db = db.Where("jobs.type = ?", f.Type)
+	}
+
+	// Full-text search over title, company, description and requirements
+	if f.Search != "" {
+		expr, args := f.SearchQuery()
+		db = db.Where("jobs.search_vector @@ "+expr, args...)
+	}
+
+	// A job matches the salary bounds when its range overlaps them
+	if f.SalaryMin != nil {
+		db = db.Where("COALESCE(jobs.salary_max, jobs.salary_min) >= ?", *f.SalaryMin)
+	}
+	if f.SalaryMax != nil {
+		db = db.Where("COALESCE(jobs.salary_min, jobs.salary_max) <= ?", *f.SalaryMax)
+	}
+	if f.Currency != "" {
+		db = db.Where("jobs.currency = ?", f.Currency)
+	}
+	if f.SalaryPeriod != "" {
+		db = db.Where("jobs.salary_period = ?", f.SalaryPeriod)
+	}
+
+	if f.WorkMode != "" {
+		db = db.Where("jobs.work_mode = ?", f.WorkMode)
+	}
+	if f.Country != "" {
+		db = db.Where("jobs.country = ?", f.Country)
+	}
+
+	// The bounding box lets the coordinates index discard most jobs before
+	// the exact distance is computed
+	if f.HasPoint() {
+		minLat, maxLat, minLon, maxLon, wraps := geo.BoundingBox(*f.Latitude, *f.Longitude, *f.RadiusKm)
+		db = db.Where("jobs.latitude BETWEEN ? AND ?", minLat, maxLat)
+		if !wraps {
+			db = db.Where("jobs.longitude BETWEEN ? AND ?", minLon, maxLon)
+		}
+		expr, args := f.DistanceExpr()
+		db = db.Where(expr+" <= ?", append(args, *f.RadiusKm)...)
+	}
+	return db
+}
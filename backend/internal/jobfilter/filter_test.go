@@ -0,0 +1,34 @@
+package jobfilter
+
+import (
+	"net/url"
+	"testing"
+)
+
+func TestSalaryDefaults(t *testing.T) {
+	tests := []struct {
+		query        string
+		wantCurrency string
+		wantPeriod   string
+	}{
+		{"salary_min=1000", "RUB", "month"},
+		{"salary_max=50&currency=usd&salary_period=Hour", "USD", "hour"},
+		{"currency=EUR", "EUR", ""},
+		{"search=go", "", ""},
+	}
+	for _, tt := range tests {
+		values, _ := url.ParseQuery(tt.query)
+		f, err := FromQuery(values)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.query, err)
+		}
+		if f.Currency != tt.wantCurrency || f.SalaryPeriod != tt.wantPeriod {
+			t.Errorf("%s: got %q per %q, want %q per %q", tt.query, f.Currency, f.SalaryPeriod, tt.wantCurrency, tt.wantPeriod)
+		}
+	}
+
+	values, _ := url.ParseQuery("salary_min=1000&salary_period=week")
+	if _, err := FromQuery(values); err == nil {
+		t.Fatal("expected an error for an unknown salary_period")
+	}
+}
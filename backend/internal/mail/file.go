@@ -0,0 +1,30 @@
+package mail
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// FileSink writes every message as an .eml file into a directory instead of
+// sending it, which is handy for local development.
+type FileSink struct {
+	dir  string
+	from string
+}
+
+func NewFileSink(dir, from string) (*FileSink, error) {
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, fmt.Errorf("mail: create %s: %w", dir, err)
+	}
+	return &FileSink{dir: dir, from: from}, nil
+}
+
+func (s *FileSink) Send(ctx context.Context, msg Message) error {
+	recipient := strings.NewReplacer("@", "_at_", "/", "_", "\\", "_").Replace(msg.To)
+	name := fmt.Sprintf("%s-%s.eml", time.Now().Format("20060102T150405.000000000"), recipient)
+	return os.WriteFile(filepath.Join(s.dir, name), render(s.from, msg), 0o640)
+}
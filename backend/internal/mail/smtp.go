@@ -0,0 +1,41 @@
+package mail
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/smtp"
+)
+
+type SMTPConfig struct {
+	Host     string
+	Port     int
+	Username string
+	Password string
+	From     string
+}
+
+// SMTP sends email through an SMTP relay, using STARTTLS when the server offers it.
+type SMTP struct {
+	cfg SMTPConfig
+}
+
+func NewSMTP(cfg SMTPConfig) (*SMTP, error) {
+	if cfg.Host == "" {
+		return nil, errors.New("mail: SMTP_HOST is required")
+	}
+	return &SMTP{cfg: cfg}, nil
+}
+
+func (m *SMTP) Send(ctx context.Context, msg Message) error {
+	var auth smtp.Auth
+	if m.cfg.Username != "" {
+		auth = smtp.PlainAuth("", m.cfg.Username, m.cfg.Password, m.cfg.Host)
+	}
+
+	addr := fmt.Sprintf("%s:%d", m.cfg.Host, m.cfg.Port)
+	if err := smtp.SendMail(addr, auth, m.cfg.From, []string{msg.To}, render(m.cfg.From, msg)); err != nil {
+		return fmt.Errorf("mail: send to %s: %w", msg.To, err)
+	}
+	return nil
+}
@@ -1,6 +1,7 @@
 package middleware
 
 import (
+	"context"
 	"net/http"
 	"strings"
 
@@ -9,35 +10,100 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
-func AuthMiddleware() gin.HandlerFunc {
+// Sessions tells whether the session of an access token is still active;
+// repository.UserRepository implements it.
+type Sessions interface {
+	SessionActive(ctx context.Context, userID uint, sessionID string) (bool, error)
+}
+
+func AuthMiddleware(sessions Sessions) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		authHeader := c.GetHeader("Authorization")
-		if authHeader == "" {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Authorization header required"})
+		claims, status, message := authenticate(c, sessions)
+		if status != 0 {
+			c.JSON(status, gin.H{"error": message})
 			c.Abort()
 			return
 		}
 
-		tokenString := strings.TrimPrefix(authHeader, "Bearer ")
-		if tokenString == authHeader {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Bearer token required"})
-			c.Abort()
-			return
-		}
+		setIdentity(c, claims)
+		c.Next()
+	}
+}
 
-		userID, role, err := utils.ValidateJWT(tokenString)
-		if err != nil {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid token"})
-			c.Abort()
-			return
+// OptionalAuthMiddleware identifies the caller when a valid token is sent but
+// lets anonymous requests (and requests with an unusable token) through, so
+// public endpoints can personalize their responses.
+func OptionalAuthMiddleware(sessions Sessions) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.GetHeader("Authorization") != "" {
+			if claims, status, _ := authenticate(c, sessions); status == 0 {
+				setIdentity(c, claims)
+			}
 		}
+		c.Next()
+	}
+}
 
-		c.Set("userID", userID)
-		c.Set("role", role)
+// StreamToken lets EventSource connections, which cannot send headers, pass
+// their access token as ?access_token= on the given paths. It moves the token
+// into the Authorization header and out of the URL, so it must run before the
+// request logger to keep tokens out of the logs.
+func StreamToken(paths ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		for _, path := range paths {
+			if c.Request.URL.Path != path {
+				continue
+			}
+			query := c.Request.URL.Query()
+			if token := query.Get("access_token"); token != "" {
+				query.Del("access_token")
+				c.Request.URL.RawQuery = query.Encode()
+				if c.GetHeader("Authorization") == "" {
+					c.Request.Header.Set("Authorization", "Bearer "+token)
+				}
+			}
+		}
 		c.Next()
 	}
 }
 
+// authenticate validates the bearer token of the request. On failure it
+// returns the HTTP status and error message to respond with.
+func authenticate(c *gin.Context, sessions Sessions) (utils.Claims, int, string) {
+	authHeader := c.GetHeader("Authorization")
+	if authHeader == "" {
+		return utils.Claims{}, http.StatusUnauthorized, "Authorization header required"
+	}
+
+	tokenString := strings.TrimPrefix(authHeader, "Bearer ")
+	if tokenString == authHeader {
+		return utils.Claims{}, http.StatusUnauthorized, "Bearer token required"
+	}
+
+	claims, err := utils.ValidateJWT(tokenString)
+	if err != nil {
+		return utils.Claims{}, http.StatusUnauthorized, "Invalid token"
+	}
+
+	// Access tokens are only valid while their session has not been logged out
+	active, err := sessions.SessionActive(c.Request.Context(), claims.UserID, claims.SessionID)
+	if err != nil {
+		return utils.Claims{}, http.StatusInternalServerError, "Failed to verify session"
+	}
+	if !active {
+		return utils.Claims{}, http.StatusUnauthorized, "Token has been revoked"
+	}
+
+	return claims, 0, ""
+}
+
+func setIdentity(c *gin.Context, claims utils.Claims) {
+	c.Set("userID", claims.UserID)
+	c.Set("role", claims.Role)
+	c.Set("sessionID", claims.SessionID)
+	c.Set("tokenExpiresAt", claims.ExpiresAt)
+}
+
 func AdminMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		role, exists := c.Get("role")
@@ -0,0 +1,57 @@
+package migrate
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"job-search-backend/migrations"
+)
+
+func TestEmbeddedMigrations(t *testing.T) {
+	list, err := Load(migrations.FS)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, migration := range list {
+		if migration.Version != uint(i+1) {
+			t.Errorf("%s: versions should be consecutive from 1", migration)
+		}
+	}
+}
+
+func TestLoadRequiresBothDirections(t *testing.T) {
+	fsys := fstest.MapFS{
+		"0001_init.up.sql":   {Data: []byte("CREATE TABLE a (id int);")},
+		"0001_init.down.sql": {Data: []byte("DROP TABLE a;")},
+		"0002_more.up.sql":   {Data: []byte("CREATE TABLE b (id int);")},
+	}
+	if _, err := Load(fsys); err == nil {
+		t.Fatal("expected an error for a migration without a down file")
+	}
+}
+
+func TestCreate(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "seed.sql"), []byte("-- not a migration"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, name := range []string{"init", "add_index"} {
+		if _, err := Create(dir, name); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := Create(dir, "Add Index"); err == nil {
+		t.Fatal("expected an error for an invalid name")
+	}
+
+	list, err := Load(os.DirFS(dir))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list) != 2 || list[1].String() != "0002_add_index" {
+		t.Fatalf("unexpected migrations %v", list)
+	}
+}
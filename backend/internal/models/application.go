@@ -0,0 +1,76 @@
+package models
+
+import "time"
+
+// Application pipeline statuses.
+const (
+	ApplicationStatusApplied   = "applied"
+	ApplicationStatusScreening = "screening"
+	ApplicationStatusInterview = "interview"
+	ApplicationStatusOffer     = "offer"
+	ApplicationStatusHired     = "hired"
+	ApplicationStatusRejected  = "rejected"
+	ApplicationStatusWithdrawn = "withdrawn"
+)
+
+// applicationTransitions lists the statuses an application may move to from
+// each status. Hired, rejected and withdrawn are final.
+var applicationTransitions = map[string][]string{
+	ApplicationStatusApplied:   {ApplicationStatusScreening, ApplicationStatusInterview, ApplicationStatusRejected, ApplicationStatusWithdrawn},
+	ApplicationStatusScreening: {ApplicationStatusInterview, ApplicationStatusRejected, ApplicationStatusWithdrawn},
+	ApplicationStatusInterview: {ApplicationStatusOffer, ApplicationStatusRejected, ApplicationStatusWithdrawn},
+	ApplicationStatusOffer:     {ApplicationStatusHired, ApplicationStatusRejected, ApplicationStatusWithdrawn},
+	ApplicationStatusHired:     {},
+	ApplicationStatusRejected:  {},
+	ApplicationStatusWithdrawn: {},
+}
+
+// AllowedApplicationTransitions returns the statuses reachable from status.
+func AllowedApplicationTransitions(status string) []string {
+	return applicationTransitions[status]
+}
+
+// CanTransitionApplication reports whether an application may move from one status to another.
+func CanTransitionApplication(from, to string) bool {
+	for _, next := range applicationTransitions[from] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+// ApplicationStatusEvent is one entry of an application's status timeline.
+// The first event of every application has an empty FromStatus.
+type ApplicationStatusEvent struct {
+	ID            uint      `json:"id" gorm:"primaryKey"`
+	ApplicationID uint      `json:"application_id" gorm:"index;not null"`
+	FromStatus    string    `json:"from_status"`
+	ToStatus      string    `json:"to_status" gorm:"not null"`
+	ChangedByID   uint      `json:"changed_by_id" gorm:"not null"`
+	ChangedBy     User      `json:"changed_by" gorm:"foreignKey:ChangedByID"`
+	Note          string    `json:"note" gorm:"type:text"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// ApplicationMessage is one message in the conversation between the applicant
+// and the employer side of an application. ReadAt is set once the other side
+// has read it.
+type ApplicationMessage struct {
+	ID            uint                `json:"id" gorm:"primaryKey"`
+	ApplicationID uint                `json:"application_id" gorm:"index;not null"`
+	SenderID      uint                `json:"sender_id" gorm:"not null"`
+	Sender        User                `json:"sender" gorm:"foreignKey:SenderID"`
+	Body          string              `json:"body" gorm:"type:text;not null"`
+	Attachments   []MessageAttachment `json:"attachments" gorm:"foreignKey:MessageID"`
+	ReadAt        *time.Time          `json:"read_at"`
+	CreatedAt     time.Time           `json:"created_at"`
+}
+
+// MessageAttachment references a file stored elsewhere, e.g. a shared document.
+type MessageAttachment struct {
+	ID        uint   `json:"id" gorm:"primaryKey"`
+	MessageID uint   `json:"message_id" gorm:"index;not null"`
+	Name      string `json:"name" gorm:"not null"`
+	URL       string `json:"url" gorm:"not null"`
+}
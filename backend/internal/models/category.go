@@ -0,0 +1,54 @@
+package models
+
+import "time"
+
+// Category is a job category. Categories form a tree through ParentID; jobs
+// reference a category by its slug in jobs.category.
+type Category struct {
+	ID        uint       `json:"id" gorm:"primaryKey"`
+	Slug      string     `json:"slug" gorm:"uniqueIndex;not null"`
+	ParentID  *uint      `json:"parent_id" gorm:"index"`
+	NameRu    string     `json:"name_ru" gorm:"not null"`
+	NameEn    string     `json:"name_en" gorm:"not null"`
+	Position  int        `json:"position" gorm:"default:0"` // order among siblings
+	Children  []Category `json:"children,omitempty" gorm:"foreignKey:ParentID"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+
+	// Display name in the language of the request
+	Name string `json:"name" gorm:"-"`
+}
+
+// EmploymentType is a kind of employment such as full-time or internship;
+// jobs reference it by its slug in jobs.type.
+type EmploymentType struct {
+	ID        uint      `json:"id" gorm:"primaryKey"`
+	Slug      string    `json:"slug" gorm:"uniqueIndex;not null"`
+	NameRu    string    `json:"name_ru" gorm:"not null"`
+	NameEn    string    `json:"name_en" gorm:"not null"`
+	Position  int       `json:"position" gorm:"default:0"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	Name string `json:"name" gorm:"-"`
+}
+
+// LocalizedName returns the English name for "en" and the Russian one otherwise.
+func LocalizedName(lang, ru, en string) string {
+	if lang == "en" && en != "" {
+		return en
+	}
+	return ru
+}
+
+// Localize sets Name of the category and its loaded children.
+func (c *Category) Localize(lang string) {
+	c.Name = LocalizedName(lang, c.NameRu, c.NameEn)
+	for i := range c.Children {
+		c.Children[i].Localize(lang)
+	}
+}
+
+func (t *EmploymentType) Localize(lang string) {
+	t.Name = LocalizedName(lang, t.NameRu, t.NameEn)
+}
@@ -0,0 +1,41 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	CompanyRoleOwner     = "owner"
+	CompanyRoleRecruiter = "recruiter"
+)
+
+type Company struct {
+	ID          uint           `json:"id" gorm:"primaryKey"`
+	Name        string         `json:"name" gorm:"not null"`
+	Slug        string         `json:"slug" gorm:"uniqueIndex;not null"`
+	Description string         `json:"description" gorm:"type:text"`
+	Website     string         `json:"website"`
+	LogoURL     string         `json:"logo_url"`
+	Location    string         `json:"location"`
+	Size        string         `json:"size"` // 1-10, 11-50, 51-200, 201-500, 501-1000, 1000+
+	CreatedAt   time.Time      `json:"created_at"`
+	UpdatedAt   time.Time      `json:"updated_at"`
+	DeletedAt   gorm.DeletedAt `json:"-" gorm:"index"`
+
+	Members []CompanyMember `json:"members,omitempty" gorm:"foreignKey:CompanyID"`
+}
+
+// CompanyMember links an employer account to a company. Owners manage the
+// company profile and its members; every member can manage the company's jobs
+// and their applications.
+type CompanyMember struct {
+	ID        uint      `json:"id" gorm:"primaryKey"`
+	CompanyID uint      `json:"company_id" gorm:"uniqueIndex:idx_company_members_company_user;not null"`
+	Company   *Company  `json:"company,omitempty" gorm:"foreignKey:CompanyID"`
+	UserID    uint      `json:"user_id" gorm:"uniqueIndex:idx_company_members_company_user;index;not null"`
+	User      *User     `json:"user,omitempty" gorm:"foreignKey:UserID"`
+	Role      string    `json:"role" gorm:"not null;default:'recruiter'"`
+	CreatedAt time.Time `json:"created_at"`
+}
@@ -0,0 +1,43 @@
+package models
+
+import "time"
+
+// Interview statuses.
+const (
+	InterviewStatusProposed            = "proposed"
+	InterviewStatusAccepted            = "accepted"
+	InterviewStatusDeclined            = "declined"
+	InterviewStatusRescheduleRequested = "reschedule_requested"
+	InterviewStatusCancelled           = "cancelled"
+)
+
+// Interview is an interview slot offered to the candidate of an application.
+// The candidate may accept it, decline it or propose another time, which the
+// employer side answers by rescheduling.
+type Interview struct {
+	ID               uint            `json:"id" gorm:"primaryKey"`
+	ApplicationID    uint            `json:"application_id" gorm:"index;not null"`
+	Application      *JobApplication `json:"application,omitempty" gorm:"foreignKey:ApplicationID"`
+	ScheduledByID    uint            `json:"scheduled_by_id" gorm:"not null"`
+	StartsAt         time.Time       `json:"starts_at" gorm:"index;not null"`
+	DurationMinutes  int             `json:"duration_minutes" gorm:"not null"`
+	Location         string          `json:"location"`
+	VideoURL         string          `json:"video_url"`
+	Status           string          `json:"status" gorm:"index;default:'proposed'"` // see InterviewStatus* constants
+	ProposedStartsAt *time.Time      `json:"proposed_starts_at"`                     // alternative suggested by the candidate
+	CandidateNote    string          `json:"candidate_note" gorm:"type:text"`
+	Sequence         int             `json:"-"` // iCalendar revision, bumped on every change
+	Interviewers     []User          `json:"interviewers" gorm:"many2many:interview_interviewers"`
+	CreatedAt        time.Time       `json:"created_at"`
+	UpdatedAt        time.Time       `json:"updated_at"`
+}
+
+// EndsAt returns the end of the interview slot.
+func (i *Interview) EndsAt() time.Time {
+	return i.StartsAt.Add(time.Duration(i.DurationMinutes) * time.Minute)
+}
+
+// IsOpen reports whether the interview still takes up the interviewers' time.
+func (i *Interview) IsOpen() bool {
+	return i.Status != InterviewStatusDeclined && i.Status != InterviewStatusCancelled
+}
@@ -3,26 +3,86 @@ package models
 import (
 	"time"
 
+	"job-search-backend/internal/utils"
+
 	"gorm.io/gorm"
 )
 
+// Why a job stopped accepting applications.
+const (
+	JobCloseReasonManual  = "manual"
+	JobCloseReasonExpired = "expired"
+	JobCloseReasonFilled  = "max_applications"
+)
+
 type Job struct {
-	ID          uint           `json:"id" gorm:"primaryKey"`
-	Title       string         `json:"title" gorm:"not null"`
-	Description string         `json:"description" gorm:"type:text"`
-	Company     string         `json:"company" gorm:"not null"`
-	Location    string         `json:"location"`
-	Salary      string         `json:"salary"`
-	Type        string         `json:"type"` // full-time, part-time, contract
-	Category    string         `json:"category"`
-	Requirements string        `json:"requirements" gorm:"type:text"`
-	Benefits    string         `json:"benefits" gorm:"type:text"`
-	EmployerID  uint           `json:"employer_id" gorm:"not null"`
-	Employer    User           `json:"employer" gorm:"foreignKey:EmployerID"`
-	IsActive    bool           `json:"is_active" gorm:"default:true"`
-	CreatedAt   time.Time      `json:"created_at"`
-	UpdatedAt   time.Time      `json:"updated_at"`
-	DeletedAt   gorm.DeletedAt `json:"-" gorm:"index"`
+	ID              uint           `json:"id" gorm:"primaryKey"`
+	Title           string         `json:"title" gorm:"not null"`
+	Description     string         `json:"description" gorm:"type:text"`
+	Company         string         `json:"company" gorm:"not null"` // display name, copied from CompanyProfile when set
+	CompanyID       *uint          `json:"company_id" gorm:"index"`
+	CompanyProfile  *Company       `json:"company_profile,omitempty" gorm:"foreignKey:CompanyID"`
+	Location        string         `json:"location"`
+	WorkMode        string         `json:"work_mode" gorm:"default:'onsite';index"` // onsite, remote, hybrid
+	SalaryMin       *int           `json:"salary_min" gorm:"index"`
+	SalaryMax       *int           `json:"salary_max" gorm:"index"`
+	Currency        string         `json:"currency" gorm:"size:3;default:'RUB'"` // RUB, USD, EUR
+	SalaryPeriod    string         `json:"salary_period" gorm:"default:'month'"` // hour, month, year
+	Salary          string         `json:"salary" gorm:"-"`                      // human-readable form of the fields above
+	Type            string         `json:"type"`                                 // EmploymentType slug
+	Category        string         `json:"category"`                             // Category slug
+	Requirements    string         `json:"requirements" gorm:"type:text"`
+	Benefits        string         `json:"benefits" gorm:"type:text"`
+	EmployerID      uint           `json:"employer_id" gorm:"not null"`
+	Employer        User           `json:"employer" gorm:"foreignKey:EmployerID"`
+	IsActive        bool           `json:"is_active" gorm:"default:true"`
+	PublishAt       *time.Time     `json:"publish_at" gorm:"index"` // the scheduler activates the job at this time
+	ExpiresAt       *time.Time     `json:"expires_at" gorm:"index"` // the scheduler closes the job at this time
+	MaxApplications *int           `json:"max_applications"`        // the job closes once it has this many applications
+	ClosedAt        *time.Time     `json:"closed_at"`
+	CloseReason     string         `json:"close_reason,omitempty"` // see JobCloseReason* constants
+	CreatedAt       time.Time      `json:"created_at"`
+	UpdatedAt       time.Time      `json:"updated_at"`
+	DeletedAt       gorm.DeletedAt `json:"-" gorm:"index"`
+
+	// Structured location, geocoded from Location when not given
+	Place
+
+	// Filled only by full-text search queries; search_vector itself is a
+	// generated column maintained by PostgreSQL (see migrations/).
+	SearchRank float64 `json:"rank,omitempty" gorm:"->;-:migration"`
+	Headline   string  `json:"headline,omitempty" gorm:"->;-:migration"`
+
+	// Filled only by radius searches
+	Distance *float64 `json:"distance_km,omitempty" gorm:"column:distance_km;->;-:migration"`
+
+	// Set only when the request is authenticated
+	IsSaved *bool `json:"is_saved,omitempty" gorm:"-"`
+}
+
+func (j *Job) AfterFind(tx *gorm.DB) error {
+	j.Salary = utils.FormatSalary(j.SalaryMin, j.SalaryMax, j.Currency, j.SalaryPeriod)
+	return nil
+}
+
+func (j *Job) AfterSave(tx *gorm.DB) error {
+	return j.AfterFind(tx)
+}
+
+// Close deactivates the job unless it has already been closed. It reports
+// whether this call closed it, so that concurrent closers notify only once.
+func (j *Job) Close(tx *gorm.DB, reason string, now time.Time) (bool, error) {
+	result := tx.Model(&Job{}).
+		Where("id = ? AND closed_at IS NULL", j.ID).
+		Updates(map[string]interface{}{"is_active": false, "closed_at": now, "close_reason": reason})
+	if result.Error != nil || result.RowsAffected == 0 {
+		return false, result.Error
+	}
+
+	j.IsActive = false
+	j.ClosedAt = &now
+	j.CloseReason = reason
+	return true, nil
 }
 
 type JobApplication struct {
@@ -31,11 +91,18 @@ type JobApplication struct {
 	Job       Job            `json:"job" gorm:"foreignKey:JobID"`
 	UserID    uint           `json:"user_id" gorm:"not null"`
 	User      User           `json:"user" gorm:"foreignKey:UserID"`
-	Status    string         `json:"status" gorm:"default:'pending'"` // pending, accepted, rejected
+	Status    string         `json:"status" gorm:"index;default:'applied'"` // see ApplicationStatus* constants
 	Message   string         `json:"message" gorm:"type:text"`
 	CreatedAt time.Time      `json:"created_at"`
 	UpdatedAt time.Time      `json:"updated_at"`
 	DeletedAt gorm.DeletedAt `json:"-" gorm:"index"`
 }
 
-
+// SavedJob is a job bookmarked by a user.
+type SavedJob struct {
+	ID        uint      `json:"id" gorm:"primaryKey"`
+	UserID    uint      `json:"user_id" gorm:"not null;uniqueIndex:idx_saved_jobs_user_job"`
+	JobID     uint      `json:"job_id" gorm:"not null;uniqueIndex:idx_saved_jobs_user_job;index"`
+	Job       Job       `json:"job" gorm:"foreignKey:JobID"`
+	CreatedAt time.Time `json:"created_at"`
+}
@@ -0,0 +1,26 @@
+package models
+
+import "time"
+
+// Notification types.
+const (
+	NotificationSavedSearch          = "saved_search"
+	NotificationApplicationReceived  = "application_received"
+	NotificationApplicationStatus    = "application_status"
+	NotificationApplicationWithdrawn = "application_withdrawn"
+	NotificationApplicationMessage   = "application_message"
+	NotificationJobClosed            = "job_closed"
+	NotificationInterview            = "interview"
+)
+
+// Notification is an in-app message shown to a user.
+type Notification struct {
+	ID        uint       `json:"id" gorm:"primaryKey"`
+	UserID    uint       `json:"user_id" gorm:"index;not null"`
+	Type      string     `json:"type" gorm:"not null"`
+	Title     string     `json:"title" gorm:"not null"`
+	Body      string     `json:"body" gorm:"type:text"`
+	Link      string     `json:"link"` // frontend path to open
+	ReadAt    *time.Time `json:"read_at"`
+	CreatedAt time.Time  `json:"created_at"`
+}
@@ -0,0 +1,62 @@
+package models
+
+import (
+	"strings"
+
+	"job-search-backend/internal/geo"
+)
+
+// Work modes of a job, or the preferred one of a job seeker.
+const (
+	WorkModeOnsite = "onsite"
+	WorkModeRemote = "remote"
+	WorkModeHybrid = "hybrid"
+)
+
+// Place is the structured location shared by jobs and profiles. It is
+// embedded next to the free-text location shown to users.
+type Place struct {
+	City      string   `json:"city"`
+	Country   string   `json:"country" gorm:"size:2;index"` // ISO 3166-1 alpha-2
+	Latitude  *float64 `json:"latitude"`
+	Longitude *float64 `json:"longitude"`
+}
+
+// Geocode fills the missing city, country and coordinates from the offline
+// gazetteer, looking up City or, when it is empty, the free-text location.
+// It reports whether a gazetteer entry was found.
+func (p *Place) Geocode(location string) bool {
+	name := p.City
+	if name == "" {
+		name = location
+	}
+	city, ok := geo.Lookup(name)
+	if !ok {
+		return false
+	}
+
+	if p.City == "" {
+		p.City = city.Name
+	}
+	if p.Country == "" {
+		p.Country = city.Country
+	}
+	if p.Latitude == nil || p.Longitude == nil {
+		lat, lon := city.Latitude, city.Longitude
+		p.Latitude, p.Longitude = &lat, &lon
+	}
+	return true
+}
+
+// GuessWorkMode infers the work mode from a free-text location such as
+// "Москва (удалённо)". It returns an empty string when nothing hints at one.
+func GuessWorkMode(location string) string {
+	location = strings.ToLower(strings.ReplaceAll(location, "ё", "е"))
+	switch {
+	case strings.Contains(location, "гибрид") || strings.Contains(location, "hybrid"):
+		return WorkModeHybrid
+	case strings.Contains(location, "удален") || strings.Contains(location, "remote"):
+		return WorkModeRemote
+	}
+	return ""
+}
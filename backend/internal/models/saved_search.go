@@ -0,0 +1,43 @@
+package models
+
+import (
+	"time"
+
+	"job-search-backend/internal/jobfilter"
+)
+
+// How often new matches of a saved search are sent.
+const (
+	AlertFrequencyOff    = "off"
+	AlertFrequencyHourly = "hourly"
+	AlertFrequencyDaily  = "daily"
+	AlertFrequencyWeekly = "weekly"
+)
+
+// AlertInterval returns the time between two digests of the given frequency,
+// or zero when alerts are off.
+func AlertInterval(frequency string) time.Duration {
+	switch frequency {
+	case AlertFrequencyHourly:
+		return time.Hour
+	case AlertFrequencyDaily:
+		return 24 * time.Hour
+	case AlertFrequencyWeekly:
+		return 7 * 24 * time.Hour
+	}
+	return 0
+}
+
+// SavedSearch is a named set of job filters. Jobs created after
+// AlertsCheckedAt that match it are sent to the user as a digest.
+type SavedSearch struct {
+	ID              uint             `json:"id" gorm:"primaryKey"`
+	UserID          uint             `json:"user_id" gorm:"index;not null"`
+	Name            string           `json:"name" gorm:"not null"`
+	Filter          jobfilter.Filter `json:"filters" gorm:"embedded;embeddedPrefix:filter_"`
+	Frequency       string           `json:"frequency" gorm:"default:'daily'"` // see AlertFrequency* constants
+	EmailAlerts     bool             `json:"email_alerts"`
+	AlertsCheckedAt time.Time        `json:"alerts_checked_at" gorm:"index"`
+	CreatedAt       time.Time        `json:"created_at"`
+	UpdatedAt       time.Time        `json:"updated_at"`
+}
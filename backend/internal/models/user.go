@@ -6,30 +6,62 @@ import (
 	"gorm.io/gorm"
 )
 
+const (
+	RoleJobSeeker = "job_seeker"
+	RoleEmployer  = "employer"
+	RoleAdmin     = "admin"
+)
+
 type User struct {
-	ID        uint           `json:"id" gorm:"primaryKey"`
-	Email     string         `json:"email" gorm:"unique;not null"`
-	Password  string         `json:"-" gorm:"not null"`
-	Name      string         `json:"name" gorm:"not null"`
-	Role      string         `json:"role" gorm:"default:'job_seeker'"`
+	ID              uint           `json:"id" gorm:"primaryKey"`
+	Email           string         `json:"email" gorm:"unique;not null"`
+	Password        string         `json:"-" gorm:"not null"`
+	Name            string         `json:"name" gorm:"not null"`
+	Role            string         `json:"role" gorm:"default:'job_seeker'"`
+	EmailVerifiedAt *time.Time     `json:"email_verified_at"`
+	CreatedAt       time.Time      `json:"created_at"`
+	UpdatedAt       time.Time      `json:"updated_at"`
+	DeletedAt       gorm.DeletedAt `json:"-" gorm:"index"`
+
+	UserProfile *UserProfile `json:"user_profile,omitempty" gorm:"foreignKey:UserID"`
+}
+
+type UserProfile struct {
+	ID         uint   `json:"id" gorm:"primaryKey"`
+	UserID     uint   `json:"user_id" gorm:"uniqueIndex;not null"`
+	User       *User  `json:"user,omitempty" gorm:"foreignKey:UserID"`
+	Phone      string `json:"phone"`
+	Location   string `json:"location"`
+	WorkMode   string `json:"work_mode"` // preferred work mode, empty for any
+	Experience string `json:"experience"`
+	Skills     string `json:"skills"`
+	Education  string `json:"education"`
+	Resume     string `json:"resume"`
+
+	// Structured location, geocoded from Location when not given
+	Place
+
+	// Uploaded CV document; the file itself lives in storage under ResumeKey.
+	ResumeKey         string     `json:"-"`
+	ResumeFileName    string     `json:"resume_file_name,omitempty"`
+	ResumeContentType string     `json:"resume_content_type,omitempty"`
+	ResumeSize        int64      `json:"resume_size,omitempty"`
+	ResumeUploadedAt  *time.Time `json:"resume_uploaded_at,omitempty"`
+
 	CreatedAt time.Time      `json:"created_at"`
 	UpdatedAt time.Time      `json:"updated_at"`
 	DeletedAt gorm.DeletedAt `json:"-" gorm:"index"`
 }
 
-type UserProfile struct {
-	ID          uint           `json:"id" gorm:"primaryKey"`
-	UserID      uint           `json:"user_id" gorm:"not null"`
-	User        User           `json:"user" gorm:"foreignKey:UserID"`
-	Phone       string         `json:"phone"`
-	Location    string         `json:"location"`
-	Experience  string         `json:"experience"`
-	Skills      string         `json:"skills"`
-	Education   string         `json:"education"`
-	Resume      string         `json:"resume"`
-	CreatedAt   time.Time      `json:"created_at"`
-	UpdatedAt   time.Time      `json:"updated_at"`
-	DeletedAt   gorm.DeletedAt `json:"-" gorm:"index"`
+// RoleChange is an audit record of an admin changing a user's role.
+type RoleChange struct {
+	ID          uint      `json:"id" gorm:"primaryKey"`
+	UserID      uint      `json:"user_id" gorm:"index;not null"`
+	User        User      `json:"user" gorm:"foreignKey:UserID"`
+	ChangedByID uint      `json:"changed_by_id" gorm:"not null"`
+	ChangedBy   User      `json:"changed_by" gorm:"foreignKey:ChangedByID"`
+	OldRole     string    `json:"old_role"`
+	NewRole     string    `json:"new_role"`
+	Reason      string    `json:"reason" gorm:"type:text"`
+	CreatedAt   time.Time `json:"created_at"`
 }
-
-
@@ -0,0 +1,89 @@
+package notifications
+
+import "sync"
+
+// Event is a message pushed to a user's open notification streams.
+type Event struct {
+	Name string      // SSE event name
+	Data interface{} // JSON-encoded as the event data
+}
+
+// subscriberBuffer is how many events a slow stream may fall behind before
+// further events to it are dropped.
+const subscriberBuffer = 16
+
+// Hub fans events out to the streams currently open in this process.
+type Hub struct {
+	mu          sync.Mutex
+	subscribers map[uint]map[chan Event]struct{}
+	closed      bool
+}
+
+func NewHub() *Hub {
+	return &Hub{subscribers: make(map[uint]map[chan Event]struct{})}
+}
+
+// Subscribe registers a stream for the user. The returned function must be
+// called when the stream ends; it closes the channel.
+func (h *Hub) Subscribe(userID uint) (<-chan Event, func()) {
+	ch := make(chan Event, subscriberBuffer)
+
+	h.mu.Lock()
+	if h.closed {
+		h.mu.Unlock()
+		close(ch)
+		return ch, func() {}
+	}
+	if h.subscribers[userID] == nil {
+		h.subscribers[userID] = make(map[chan Event]struct{})
+	}
+	h.subscribers[userID][ch] = struct{}{}
+	h.mu.Unlock()
+
+	return ch, func() {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		if _, ok := h.subscribers[userID][ch]; !ok {
+			return
+		}
+		delete(h.subscribers[userID], ch)
+		if len(h.subscribers[userID]) == 0 {
+			delete(h.subscribers, userID)
+		}
+		close(ch)
+	}
+}
+
+// Publish sends the event to every stream of the user without blocking.
+func (h *Hub) Publish(userID uint, event Event) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for ch := range h.subscribers[userID] {
+		select {
+		case ch <- event:
+		default:
+		}
+	}
+}
+
+// HasSubscribers reports whether the user has an open stream.
+func (h *Hub) HasSubscribers(userID uint) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return len(h.subscribers[userID]) > 0
+}
+
+// Close ends every open stream, and the ones subscribed later, by closing
+// their channels. It lets the server shut down without waiting for clients
+// to disconnect.
+func (h *Hub) Close() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.closed = true
+	for userID, channels := range h.subscribers {
+		for ch := range channels {
+			close(ch)
+		}
+		delete(h.subscribers, userID)
+	}
+}
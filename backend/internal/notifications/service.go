@@ -0,0 +1,143 @@
+// Package notifications stores in-app notifications and pushes them to the
+// users' open event streams.
+package notifications
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"job-search-backend/internal/database"
+	"job-search-backend/internal/models"
+)
+
+// Stream event names.
+const (
+	EventNotification = "notification"
+	EventUnread       = "unread"
+)
+
+type Service struct {
+	Hub *Hub
+}
+
+func NewService() *Service {
+	return &Service{Hub: NewHub()}
+}
+
+// Send stores the notifications and pushes them, followed by the new unread
+// count, to the recipients' open streams.
+func (s *Service) Send(ctx context.Context, notifications ...models.Notification) error {
+	if len(notifications) == 0 {
+		return nil
+	}
+	if err := database.DB.WithContext(ctx).Create(&notifications).Error; err != nil {
+		return err
+	}
+
+	recipients := make(map[uint]bool)
+	for _, n := range notifications {
+		s.Hub.Publish(n.UserID, Event{Name: EventNotification, Data: n})
+		recipients[n.UserID] = true
+	}
+	for userID := range recipients {
+		s.PublishUnread(ctx, userID)
+	}
+	return nil
+}
+
+// UnreadCount returns the number of unread notifications of the user.
+func (s *Service) UnreadCount(ctx context.Context, userID uint) (int64, error) {
+	var count int64
+	err := database.DB.WithContext(ctx).Model(&models.Notification{}).
+		Where("user_id = ? AND read_at IS NULL", userID).
+		Count(&count).Error
+	return count, err
+}
+
+// MarkRead marks the given notifications of the user as read, or all of them
+// when no IDs are given. It returns the number of notifications changed.
+func (s *Service) MarkRead(ctx context.Context, userID uint, ids ...uint) (int64, error) {
+	query := database.DB.WithContext(ctx).Model(&models.Notification{}).
+		Where("user_id = ? AND read_at IS NULL", userID)
+	if len(ids) > 0 {
+		query = query.Where("id IN ?", ids)
+	}
+
+	result := query.Update("read_at", time.Now())
+	if result.Error != nil {
+		return 0, result.Error
+	}
+	if result.RowsAffected > 0 {
+		s.PublishUnread(ctx, userID)
+	}
+	return result.RowsAffected, nil
+}
+
+// PublishUnread pushes the current unread count to the user's open streams.
+func (s *Service) PublishUnread(ctx context.Context, userID uint) {
+	if !s.Hub.HasSubscribers(userID) {
+		return
+	}
+	if count, err := s.UnreadCount(ctx, userID); err == nil {
+		s.Hub.Publish(userID, Event{Name: EventUnread, Data: map[string]int64{"unread": count}})
+	}
+}
+
+// NotifyJobClosed tells applicants whose applications are still open that the
+// job no longer accepts applications. When the job was closed automatically
+// the members of its company, or for jobs without a company the employer who
+// posted it, are told as well.
+func (s *Service) NotifyJobClosed(ctx context.Context, job *models.Job) error {
+	var applicantIDs []uint
+	if err := database.DB.WithContext(ctx).Model(&models.JobApplication{}).
+		Where("job_id = ? AND status NOT IN ?", job.ID, []string{
+			models.ApplicationStatusHired,
+			models.ApplicationStatusRejected,
+			models.ApplicationStatusWithdrawn,
+		}).
+		Pluck("user_id", &applicantIDs).Error; err != nil {
+		return err
+	}
+
+	notes := make([]models.Notification, 0, len(applicantIDs)+1)
+	for _, id := range applicantIDs {
+		notes = append(notes, models.Notification{
+			UserID: id,
+			Type:   models.NotificationJobClosed,
+			Title:  "Вакансия закрыта",
+			Body:   fmt.Sprintf("Вакансия «%s» (%s) больше не принимает отклики", job.Title, job.Company),
+			Link:   "/applications",
+		})
+	}
+
+	var reason string
+	switch job.CloseReason {
+	case models.JobCloseReasonExpired:
+		reason = "истёк срок публикации"
+	case models.JobCloseReasonFilled:
+		reason = "набрано максимальное количество откликов"
+	}
+	if reason != "" {
+		var managerIDs []uint
+		if job.CompanyID == nil {
+			managerIDs = []uint{job.EmployerID}
+		} else if err := database.DB.WithContext(ctx).Model(&models.CompanyMember{}).
+			Where("company_id = ?", *job.CompanyID).
+			Order("id").
+			Pluck("user_id", &managerIDs).Error; err != nil {
+			return err
+		}
+		for _, id := range managerIDs {
+			notes = append(notes, models.Notification{
+				UserID: id,
+				Type:   models.NotificationJobClosed,
+				Title:  "Вакансия закрыта автоматически",
+				Body:   fmt.Sprintf("Вакансия «%s» закрыта: %s", job.Title, reason),
+				Link:   fmt.Sprintf("/jobs/%d", job.ID),
+			})
+		}
+	}
+
+	return s.Send(ctx, notes...)
+}
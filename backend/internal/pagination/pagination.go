@@ -0,0 +1,259 @@
+// Package pagination implements keyset (cursor) pagination for list endpoints.
+//
+// A list is ordered by an Order: a sequence of keys ending with a unique
+// column. The cursor handed out with a page holds the key values of its last
+// row, and the next page continues strictly after that row, so pages stay
+// stable while rows are inserted and deep pages cost as much as the first.
+package pagination
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	DefaultLimit = 20
+	MaxLimit     = 100
+)
+
+var (
+	ErrInvalidLimit  = errors.New("Invalid limit, expected 1 to 100")
+	ErrInvalidPage   = errors.New("Invalid page, expected a positive number")
+	ErrInvalidCursor = errors.New("Invalid cursor")
+)
+
+// Kind is the Go type a key value is decoded to from a cursor.
+type Kind int
+
+const (
+	Int Kind = iota
+	Float
+	Time
+	String
+)
+
+// Key is one ORDER BY term.
+type Key struct {
+	Expr     string        // SQL expression, may contain ? placeholders
+	Args     []interface{} // values for the placeholders in Expr
+	Kind     Kind
+	Desc     bool
+	Nullable bool // NULL values are sorted after all others
+}
+
+// Order is a named sort order. Its last key must be unique, usually the id.
+type Order struct {
+	Name string
+	Keys []Key
+}
+
+// Page holds the pagination parameters of a request.
+type Page struct {
+	Limit  int
+	Offset int // only used without a cursor, for clients that still send page
+	Cursor string
+}
+
+// FromQuery reads the limit, cursor and page query parameters. The limit
+// defaults to defaultLimit and cannot exceed MaxLimit; page is accepted for
+// backward compatibility and ignored when a cursor is given.
+func FromQuery(values url.Values, defaultLimit int) (Page, error) {
+	p := Page{Limit: defaultLimit, Cursor: values.Get("cursor")}
+
+	if limit := values.Get("limit"); limit != "" {
+		value, err := strconv.Atoi(limit)
+		if err != nil || value < 1 || value > MaxLimit {
+			return p, ErrInvalidLimit
+		}
+		p.Limit = value
+	}
+
+	if page := values.Get("page"); page != "" && p.Cursor == "" {
+		value, err := strconv.Atoi(page)
+		if err != nil || value < 1 {
+			return p, ErrInvalidPage
+		}
+		p.Offset = (value - 1) * p.Limit
+	}
+
+	return p, nil
+}
+
+// OffsetFromQuery reads the limit and page query parameters of lists that
+// are only paged by offset, ignoring any cursor.
+func OffsetFromQuery(values url.Values, defaultLimit int) (Page, error) {
+	return FromQuery(url.Values{"limit": values["limit"], "page": values["page"]}, defaultLimit)
+}
+
+// Number returns the 1-based page number of an offset page.
+func (p Page) Number() int {
+	return p.Offset/p.Limit + 1
+}
+
+type cursor struct {
+	Order  string            `json:"o"`
+	Values []json.RawMessage `json:"v"`
+}
+
+// Apply orders the query, skips past the cursor and limits it to one row
+// more than the page size, so that the caller can tell whether a next page
+// exists. It fails with ErrInvalidCursor when the cursor was not issued for
+// this order.
+func (o Order) Apply(db *gorm.DB, p Page) (*gorm.DB, error) {
+	if p.Cursor != "" {
+		values, err := o.decode(p.Cursor)
+		if err != nil {
+			return db, err
+		}
+		sql, args := o.after(values)
+		db = db.Where(sql, args...)
+	} else if p.Offset > 0 {
+		db = db.Offset(p.Offset)
+	}
+
+	for _, key := range o.Keys {
+		sql := key.Expr
+		if key.Desc {
+			sql += " DESC"
+		} else {
+			sql += " ASC"
+		}
+		if key.Nullable {
+			sql += " NULLS LAST"
+		}
+		db = db.Order(clause.Expr{SQL: sql, Vars: key.Args, WithoutParentheses: true})
+	}
+
+	return db.Limit(p.Limit + 1), nil
+}
+
+// Cursor encodes the key values of a row, in key order, into a cursor that
+// continues after it. Nil pointers stand for NULL.
+func (o Order) Cursor(values ...interface{}) string {
+	c := cursor{Order: o.Name}
+	for _, value := range values {
+		raw, err := json.Marshal(value)
+		if err != nil {
+			panic(fmt.Sprintf("pagination: cannot encode cursor value %v: %v", value, err))
+		}
+		c.Values = append(c.Values, raw)
+	}
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func (o Order) decode(s string) ([]interface{}, error) {
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	var c cursor
+	if err := json.Unmarshal(data, &c); err != nil || c.Order != o.Name || len(c.Values) != len(o.Keys) {
+		return nil, ErrInvalidCursor
+	}
+
+	values := make([]interface{}, len(o.Keys))
+	for i, key := range o.Keys {
+		raw := c.Values[i]
+		if bytes.Equal(raw, []byte("null")) {
+			if !key.Nullable {
+				return nil, ErrInvalidCursor
+			}
+			continue
+		}
+
+		var err error
+		switch key.Kind {
+		case Int:
+			var v int64
+			err = json.Unmarshal(raw, &v)
+			values[i] = v
+		case Float:
+			var v float64
+			err = json.Unmarshal(raw, &v)
+			values[i] = v
+		case Time:
+			var v time.Time
+			err = json.Unmarshal(raw, &v)
+			values[i] = v
+		default:
+			var v string
+			err = json.Unmarshal(raw, &v)
+			values[i] = v
+		}
+		if err != nil {
+			return nil, ErrInvalidCursor
+		}
+	}
+	return values, nil
+}
+
+// after builds the condition selecting the rows that sort after the given
+// key values: (k1 > v1) OR (k1 = v1 AND k2 > v2) OR ... with NULLs last.
+func (o Order) after(values []interface{}) (string, []interface{}) {
+	var terms []string
+	var args []interface{}
+
+	var prefix []string
+	var prefixArgs []interface{}
+	for i, key := range o.Keys {
+		value := values[i]
+
+		// Nothing sorts after NULL, so a NULL key only contributes equality
+		if value != nil {
+			op := " > ?"
+			if key.Desc {
+				op = " < ?"
+			}
+			cond := key.Expr + op
+			condArgs := append(append([]interface{}{}, key.Args...), value)
+			if key.Nullable {
+				cond = "(" + cond + " OR " + key.Expr + " IS NULL)"
+				condArgs = append(condArgs, key.Args...)
+			}
+			terms = append(terms, "("+strings.Join(append(append([]string{}, prefix...), cond), " AND ")+")")
+			args = append(append(args, prefixArgs...), condArgs...)
+		}
+
+		if value == nil {
+			prefix = append(prefix, key.Expr+" IS NULL")
+			prefixArgs = append(prefixArgs, key.Args...)
+		} else {
+			prefix = append(prefix, key.Expr+" = ?")
+			prefixArgs = append(append(prefixArgs, key.Args...), value)
+		}
+	}
+
+	if len(terms) == 0 {
+		return "FALSE", nil
+	}
+	return "(" + strings.Join(terms, " OR ") + ")", args
+}
+
+// SetNext adds the next_cursor to a list response and advertises the next
+// page in a Link header. An empty next means the list is exhausted.
+func SetNext(c *gin.Context, response gin.H, next string) {
+	if next == "" {
+		response["next_cursor"] = nil
+		return
+	}
+	response["next_cursor"] = next
+
+	u := *c.Request.URL
+	query := u.Query()
+	query.Del("page")
+	query.Set("cursor", next)
+	u.RawQuery = query.Encode()
+	c.Header("Link", fmt.Sprintf("<%s>; rel=\"next\"", u.RequestURI()))
+}
@@ -0,0 +1,217 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"job-search-backend/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type applicationRepository struct {
+	db *gorm.DB
+}
+
+// NewApplicationRepository returns the ApplicationRepository backed by db.
+func NewApplicationRepository(db *gorm.DB) ApplicationRepository {
+	return &applicationRepository{db: db}
+}
+
+// ManagedJobsScope restricts a query joined with jobs to the jobs the user
+// manages: those of the companies they are a member of and those without a
+// company that they posted.
+func ManagedJobsScope(userID uint) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where("((jobs.company_id IS NULL AND jobs.employer_id = ?) OR jobs.company_id IN (SELECT company_id FROM company_members WHERE user_id = ?))", userID, userID)
+	}
+}
+
+func (r *applicationRepository) FindByID(ctx context.Context, id uint) (*models.JobApplication, error) {
+	var application models.JobApplication
+	if err := r.db.WithContext(ctx).Preload("Job").First(&application, id).Error; err != nil {
+		return nil, notFound(err)
+	}
+	return &application, nil
+}
+
+func (r *applicationRepository) List(ctx context.Context, q ApplicationQuery) ([]models.JobApplication, error) {
+	query := r.db.WithContext(ctx)
+	switch {
+	case q.UserID != 0:
+		query = query.Where("job_applications.user_id = ?", q.UserID).Preload("Job").Preload("Job.Employer")
+	case q.ManagerID != 0:
+		query = query.Joins("JOIN jobs ON job_applications.job_id = jobs.id").
+			Scopes(ManagedJobsScope(q.ManagerID)).
+			Preload("Job").Preload("User")
+	case q.JobID != 0:
+		query = query.Where("job_applications.job_id = ?", q.JobID).Preload("User").Preload("User.UserProfile")
+	default:
+		query = query.Preload("Job").Preload("User")
+	}
+	if q.Status != "" {
+		query = query.Where("job_applications.status = ?", q.Status)
+	}
+
+	query, err := ApplicationOrder.Apply(query, q.Page)
+	if err != nil {
+		return nil, err
+	}
+
+	var applications []models.JobApplication
+	if err := query.Find(&applications).Error; err != nil {
+		return nil, err
+	}
+	return applications, nil
+}
+
+func (r *applicationRepository) Apply(ctx context.Context, application *models.JobApplication, job *models.Job) (bool, error) {
+	filled := false
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Lock the job so that concurrent applications cannot overshoot max_applications
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(job, application.JobID).Error; err != nil {
+			return notFound(err)
+		}
+
+		applied, err := exists(tx.Model(&models.JobApplication{}).Where("job_id = ? AND user_id = ?", application.JobID, application.UserID))
+		if err != nil {
+			return err
+		}
+		if applied {
+			return ErrAlreadyApplied
+		}
+		if !job.IsActive {
+			return ErrJobClosed
+		}
+
+		if err := tx.Create(application).Error; err != nil {
+			return err
+		}
+		if err := tx.Create(&models.ApplicationStatusEvent{
+			ApplicationID: application.ID,
+			ToStatus:      application.Status,
+			ChangedByID:   application.UserID,
+		}).Error; err != nil {
+			return err
+		}
+
+		if job.MaxApplications == nil {
+			return nil
+		}
+		var count int64
+		if err := tx.Model(&models.JobApplication{}).Where("job_id = ?", job.ID).Count(&count).Error; err != nil {
+			return err
+		}
+		if count >= int64(*job.MaxApplications) {
+			filled, err = job.Close(tx, models.JobCloseReasonFilled, time.Now())
+			return err
+		}
+		return nil
+	})
+	return filled, err
+}
+
+func (r *applicationRepository) ChangeStatus(ctx context.Context, application *models.JobApplication, status string, changedBy uint, note string) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.JobApplication{}).
+			Where("id = ? AND status = ?", application.ID, application.Status).
+			Update("status", status)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrStatusChanged
+		}
+
+		event := models.ApplicationStatusEvent{
+			ApplicationID: application.ID,
+			FromStatus:    application.Status,
+			ToStatus:      status,
+			ChangedByID:   changedBy,
+			Note:          note,
+		}
+		if err := tx.Create(&event).Error; err != nil {
+			return err
+		}
+
+		application.Status = status
+		return nil
+	})
+}
+
+func (r *applicationRepository) History(ctx context.Context, applicationID uint) ([]models.ApplicationStatusEvent, error) {
+	var events []models.ApplicationStatusEvent
+	err := r.db.WithContext(ctx).Where("application_id = ?", applicationID).
+		Preload("ChangedBy").
+		Order("created_at ASC, id ASC").
+		Find(&events).Error
+	return events, err
+}
+
+func (r *applicationRepository) UpdateMessage(ctx context.Context, application *models.JobApplication, message string) (bool, error) {
+	result := r.db.WithContext(ctx).Model(&models.JobApplication{}).
+		Where("id = ? AND status = ?", application.ID, models.ApplicationStatusApplied).
+		Update("message", message)
+	if result.Error != nil || result.RowsAffected == 0 {
+		return false, result.Error
+	}
+	application.Message = message
+	return true, nil
+}
+
+func (r *applicationRepository) ListMessages(ctx context.Context, applicationID uint, offset, limit int) ([]models.ApplicationMessage, int64, error) {
+	query := r.db.WithContext(ctx).Model(&models.ApplicationMessage{}).Where("application_id = ?", applicationID)
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var messages []models.ApplicationMessage
+	if err := query.Preload("Sender").Preload("Attachments").
+		Order("created_at DESC, id DESC").
+		Offset(offset).Limit(limit).
+		Find(&messages).Error; err != nil {
+		return nil, 0, err
+	}
+	return messages, total, nil
+}
+
+func (r *applicationRepository) CreateMessage(ctx context.Context, message *models.ApplicationMessage) error {
+	db := r.db.WithContext(ctx)
+	if err := db.Create(message).Error; err != nil {
+		return err
+	}
+	db.First(&message.Sender, message.SenderID)
+	return nil
+}
+
+func (r *applicationRepository) CountUnread(ctx context.Context, application *models.JobApplication, readerID uint) (int64, error) {
+	var unread int64
+	err := r.db.WithContext(ctx).Model(&models.ApplicationMessage{}).
+		Where("application_id = ? AND read_at IS NULL", application.ID).
+		Scopes(incomingMessages(application, readerID)).
+		Count(&unread).Error
+	return unread, err
+}
+
+func (r *applicationRepository) MarkRead(ctx context.Context, application *models.JobApplication, readerID uint, now time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).Model(&models.ApplicationMessage{}).
+		Where("application_id = ? AND read_at IS NULL", application.ID).
+		Scopes(incomingMessages(application, readerID)).
+		Update("read_at", now)
+	return result.RowsAffected, result.Error
+}
+
+// incomingMessages restricts a message query to the messages written by the
+// other side of the conversation: the employer side for the applicant and the
+// applicant for everyone else.
+func incomingMessages(application *models.JobApplication, readerID uint) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if application.UserID == readerID {
+			return db.Where("sender_id <> ?", application.UserID)
+		}
+		return db.Where("sender_id = ?", application.UserID)
+	}
+}
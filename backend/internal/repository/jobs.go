@@ -0,0 +1,314 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"job-search-backend/internal/jobfilter"
+	"job-search-backend/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const headlineOptions = "StartSel=<mark>, StopSel=</mark>, MaxFragments=2, MaxWords=30, MinWords=10"
+
+type jobRepository struct {
+	db *gorm.DB
+}
+
+// NewJobRepository returns the JobRepository backed by db.
+func NewJobRepository(db *gorm.DB) JobRepository {
+	return &jobRepository{db: db}
+}
+
+// notFound maps GORM's missing-record error onto ErrNotFound.
+func notFound(err error) error {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ErrNotFound
+	}
+	return err
+}
+
+func (r *jobRepository) FindByID(ctx context.Context, id uint) (*models.Job, error) {
+	var job models.Job
+	if err := r.db.WithContext(ctx).First(&job, id).Error; err != nil {
+		return nil, notFound(err)
+	}
+	return &job, nil
+}
+
+func (r *jobRepository) FindWithDetails(ctx context.Context, id uint) (*models.Job, error) {
+	var job models.Job
+	if err := r.db.WithContext(ctx).Preload("Employer").Preload("CompanyProfile").First(&job, id).Error; err != nil {
+		return nil, notFound(err)
+	}
+	return &job, nil
+}
+
+func (r *jobRepository) FindIncludingDeleted(ctx context.Context, id uint) (*models.Job, error) {
+	var job models.Job
+	if err := r.db.WithContext(ctx).Unscoped().First(&job, id).Error; err != nil {
+		return nil, notFound(err)
+	}
+	return &job, nil
+}
+
+func (r *jobRepository) List(ctx context.Context, q JobQuery) ([]models.Job, int64, error) {
+	query := r.db.WithContext(ctx).Model(&models.Job{})
+	if q.ActiveOnly {
+		query = query.Where("jobs.is_active = ?", true)
+	}
+	query = q.Filter.Apply(query)
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	// Ranking, highlighting and distances only make sense once the total has been counted
+	columns := []string{"jobs.*"}
+	var args []interface{}
+	if q.Filter.Search != "" {
+		searchExpr, searchArgs := q.Filter.SearchQuery()
+		headlineConfig := "russian"
+		if q.Filter.Lang == "en" {
+			headlineConfig = "english"
+		}
+		columns = append(columns,
+			"ts_rank_cd(jobs.search_vector, "+searchExpr+") AS search_rank",
+			"ts_headline('"+headlineConfig+"', jobs.description, "+searchExpr+", '"+headlineOptions+"') AS headline")
+		args = append(append(args, searchArgs...), searchArgs...)
+	}
+	if q.Filter.HasPoint() {
+		distanceExpr, distanceArgs := q.Filter.DistanceExpr()
+		columns = append(columns, distanceExpr+" AS distance_km")
+		args = append(args, distanceArgs...)
+	}
+	if len(columns) > 1 {
+		query = query.Select(strings.Join(columns, ", "), args...)
+	}
+
+	query, err := q.Order.Apply(query.Preload("Employer"), q.Page)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var jobs []models.Job
+	if err := query.Find(&jobs).Error; err != nil {
+		return nil, 0, err
+	}
+	return jobs, total, nil
+}
+
+// facetRow is one row of the GROUPING SETS query: exactly one of the grouped
+// columns is real, the GROUPING flags of the others are 1.
+type facetRow struct {
+	Category         string
+	Type             string
+	Location         string
+	WorkMode         string
+	GroupingCategory int
+	GroupingType     int
+	GroupingLocation int
+	GroupingWorkMode int
+	Count            int64
+}
+
+func (r *jobRepository) Facets(ctx context.Context, filter jobfilter.Filter, currency, period string, thresholds []int) (JobFacets, error) {
+	base := func() *gorm.DB {
+		return filter.Apply(r.db.WithContext(ctx).Model(&models.Job{}).Where("jobs.is_active = ?", true))
+	}
+	facets := JobFacets{
+		Categories: map[string]int64{},
+		Types:      map[string]int64{},
+		Locations:  map[string]int64{},
+		WorkModes:  map[string]int64{},
+	}
+
+	// One pass over the matching jobs for the value facets and the total
+	var rows []facetRow
+	if err := base().
+		Select("COALESCE(jobs.category, '') AS category, COALESCE(jobs.type, '') AS type, " +
+			"COALESCE(jobs.location, '') AS location, COALESCE(jobs.work_mode, '') AS work_mode, " +
+			"GROUPING(jobs.category) AS grouping_category, GROUPING(jobs.type) AS grouping_type, " +
+			"GROUPING(jobs.location) AS grouping_location, GROUPING(jobs.work_mode) AS grouping_work_mode, " +
+			"COUNT(*) AS count").
+		Group("GROUPING SETS ((jobs.category), (jobs.type), (jobs.location), (jobs.work_mode), ())").
+		Scan(&rows).Error; err != nil {
+		return facets, err
+	}
+	for _, row := range rows {
+		switch {
+		case row.GroupingCategory == 0:
+			facets.Categories[row.Category] += row.Count
+		case row.GroupingType == 0:
+			facets.Types[row.Type] += row.Count
+		case row.GroupingLocation == 0:
+			facets.Locations[row.Location] += row.Count
+		case row.GroupingWorkMode == 0:
+			facets.WorkModes[row.WorkMode] += row.Count
+		default:
+			facets.Total = row.Count
+		}
+	}
+
+	// Salary buckets are cumulative, counted with conditional aggregates
+	columns := []string{"COUNT(*) FILTER (WHERE jobs.salary_min IS NULL AND jobs.salary_max IS NULL)"}
+	var args []interface{}
+	for _, threshold := range thresholds {
+		columns = append(columns, "COUNT(*) FILTER (WHERE jobs.currency = ? AND jobs.salary_period = ? AND COALESCE(jobs.salary_max, jobs.salary_min) >= ?)")
+		args = append(args, currency, period, threshold)
+	}
+
+	counts := make([]int64, len(columns))
+	dest := make([]interface{}, len(columns))
+	for i := range counts {
+		dest[i] = &counts[i]
+	}
+	if err := base().Select(strings.Join(columns, ", "), args...).Row().Scan(dest...); err != nil {
+		return facets, fmt.Errorf("salary facet: %w", err)
+	}
+	facets.SalaryNotSpecified = counts[0]
+	facets.SalaryCounts = counts[1:]
+	return facets, nil
+}
+
+func (r *jobRepository) Create(ctx context.Context, job *models.Job) error {
+	return r.db.WithContext(ctx).Create(job).Error
+}
+
+func (r *jobRepository) Update(ctx context.Context, job *models.Job) error {
+	return r.db.WithContext(ctx).Omit("is_active", "closed_at", "close_reason").Save(job).Error
+}
+
+func (r *jobRepository) Delete(ctx context.Context, job *models.Job) error {
+	return r.db.WithContext(ctx).Delete(job).Error
+}
+
+func (r *jobRepository) Close(ctx context.Context, job *models.Job, reason string, now time.Time) (bool, error) {
+	return job.Close(r.db.WithContext(ctx), reason, now)
+}
+
+func (r *jobRepository) Reopen(ctx context.Context, job *models.Job) error {
+	return r.db.WithContext(ctx).Model(job).
+		Select("is_active", "closed_at", "close_reason", "publish_at", "expires_at", "max_applications").
+		Updates(job).Error
+}
+
+func (r *jobRepository) CountApplications(ctx context.Context, jobID uint) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&models.JobApplication{}).Where("job_id = ?", jobID).Count(&count).Error
+	return count, err
+}
+
+func (r *jobRepository) FindCompany(ctx context.Context, id uint) (*models.Company, error) {
+	var company models.Company
+	if err := r.db.WithContext(ctx).First(&company, id).Error; err != nil {
+		return nil, notFound(err)
+	}
+	return &company, nil
+}
+
+func (r *jobRepository) IsCompanyMember(ctx context.Context, companyID, userID uint, roles ...string) (bool, error) {
+	query := r.db.WithContext(ctx).Model(&models.CompanyMember{}).Where("company_id = ? AND user_id = ?", companyID, userID)
+	if len(roles) > 0 {
+		query = query.Where("role IN ?", roles)
+	}
+	return exists(query)
+}
+
+func (r *jobRepository) CompanyMemberIDs(ctx context.Context, companyID uint) ([]uint, error) {
+	var ids []uint
+	err := r.db.WithContext(ctx).Model(&models.CompanyMember{}).
+		Where("company_id = ?", companyID).
+		Order("id").
+		Pluck("user_id", &ids).Error
+	return ids, err
+}
+
+func (r *jobRepository) RemoveCompanyMember(ctx context.Context, companyID, userID uint) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Lock every member of the company so that concurrent removals of two
+		// owners cannot both see the other one still in place
+		var members []models.CompanyMember
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("company_id = ?", companyID).Find(&members).Error; err != nil {
+			return err
+		}
+
+		var member *models.CompanyMember
+		owners := 0
+		for i := range members {
+			if members[i].Role == models.CompanyRoleOwner {
+				owners++
+			}
+			if members[i].UserID == userID {
+				member = &members[i]
+			}
+		}
+		if member == nil {
+			return ErrNotFound
+		}
+		if member.Role == models.CompanyRoleOwner && owners <= 1 {
+			return ErrLastOwner
+		}
+		return tx.Delete(member).Error
+	})
+}
+
+func (r *jobRepository) CategoryExists(ctx context.Context, slug string) (bool, error) {
+	return exists(r.db.WithContext(ctx).Model(&models.Category{}).Where("slug = ?", slug))
+}
+
+func (r *jobRepository) EmploymentTypeExists(ctx context.Context, slug string) (bool, error) {
+	return exists(r.db.WithContext(ctx).Model(&models.EmploymentType{}).Where("slug = ?", slug))
+}
+
+// exists reports whether the query matches any row.
+func exists(query *gorm.DB) (bool, error) {
+	var count int64
+	if err := query.Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+func (r *jobRepository) SaveJob(ctx context.Context, userID, jobID uint) error {
+	return r.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&models.SavedJob{UserID: userID, JobID: jobID}).Error
+}
+
+func (r *jobRepository) UnsaveJob(ctx context.Context, userID, jobID uint) error {
+	return r.db.WithContext(ctx).Where("user_id = ? AND job_id = ?", userID, jobID).Delete(&models.SavedJob{}).Error
+}
+
+func (r *jobRepository) ListSaved(ctx context.Context, userID uint, offset, limit int) ([]models.SavedJob, int64, error) {
+	query := r.db.WithContext(ctx).Model(&models.SavedJob{}).
+		Joins("JOIN jobs ON jobs.id = saved_jobs.job_id AND jobs.deleted_at IS NULL").
+		Where("saved_jobs.user_id = ?", userID)
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var saved []models.SavedJob
+	if err := query.Preload("Job.Employer").
+		Order("saved_jobs.created_at DESC").
+		Offset(offset).Limit(limit).
+		Find(&saved).Error; err != nil {
+		return nil, 0, err
+	}
+	return saved, total, nil
+}
+
+func (r *jobRepository) SavedJobIDs(ctx context.Context, userID uint, jobIDs []uint) ([]uint, error) {
+	var savedIDs []uint
+	err := r.db.WithContext(ctx).Model(&models.SavedJob{}).
+		Where("user_id = ? AND job_id IN ?", userID, jobIDs).
+		Pluck("job_id", &savedIDs).Error
+	return savedIDs, err
+}
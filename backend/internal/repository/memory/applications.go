@@ -0,0 +1,279 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"job-search-backend/internal/models"
+	"job-search-backend/internal/pagination"
+	"job-search-backend/internal/repository"
+)
+
+type applicationRepository struct {
+	s *Store
+}
+
+func (r *applicationRepository) FindByID(ctx context.Context, id uint) (*models.JobApplication, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	application, ok := r.s.applications[id]
+	if !ok {
+		return nil, repository.ErrNotFound
+	}
+	if job, ok := r.s.job(application.JobID); ok {
+		application.Job = loaded(job)
+	}
+	return &application, nil
+}
+
+// managesJob mirrors repository.ManagedJobsScope. The caller holds the lock.
+func (s *Store) managesJob(job models.Job, userID uint) bool {
+	if job.CompanyID == nil {
+		return job.EmployerID == userID
+	}
+	return s.isCompanyMember(*job.CompanyID, userID)
+}
+
+func (r *applicationRepository) List(ctx context.Context, q repository.ApplicationQuery) ([]models.JobApplication, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	var list []models.JobApplication
+	for _, application := range r.s.applications {
+		job, jobFound := r.s.job(application.JobID)
+		switch {
+		case q.UserID != 0 && application.UserID != q.UserID,
+			q.JobID != 0 && application.JobID != q.JobID,
+			q.ManagerID != 0 && !(jobFound && r.s.managesJob(job, q.ManagerID)),
+			q.Status != "" && application.Status != q.Status:
+			continue
+		}
+
+		if q.JobID == 0 && jobFound {
+			application.Job = loaded(job)
+			if q.UserID != 0 {
+				application.Job.Employer = r.s.user(job.EmployerID)
+			}
+		}
+		if q.UserID == 0 {
+			application.User = r.s.user(application.UserID)
+		}
+		list = append(list, application)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		if !list[i].CreatedAt.Equal(list[j].CreatedAt) {
+			return list[i].CreatedAt.After(list[j].CreatedAt)
+		}
+		return list[i].ID > list[j].ID
+	})
+
+	start := q.Page.Offset
+	if q.Page.Cursor != "" {
+		start = -1
+		for i, application := range list {
+			if repository.ApplicationCursor(application) == q.Page.Cursor {
+				start = i + 1
+				break
+			}
+		}
+		if start < 0 {
+			return nil, pagination.ErrInvalidCursor
+		}
+	}
+	if start > len(list) {
+		start = len(list)
+	}
+	list = list[start:]
+	if len(list) > q.Page.Limit+1 {
+		list = list[:q.Page.Limit+1]
+	}
+	return list, nil
+}
+
+func (r *applicationRepository) Apply(ctx context.Context, application *models.JobApplication, job *models.Job) (bool, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	stored, ok := r.s.job(application.JobID)
+	if !ok {
+		return false, repository.ErrNotFound
+	}
+	*job = loaded(stored)
+
+	for _, existing := range r.s.applications {
+		if existing.JobID == application.JobID && existing.UserID == application.UserID {
+			return false, repository.ErrAlreadyApplied
+		}
+	}
+	if !job.IsActive {
+		return false, repository.ErrJobClosed
+	}
+
+	application.ID = r.s.id("job_applications")
+	application.CreatedAt, application.UpdatedAt = now(), now()
+	r.s.applications[application.ID] = *application
+	r.s.addEvent(models.ApplicationStatusEvent{
+		ApplicationID: application.ID,
+		ToStatus:      application.Status,
+		ChangedByID:   application.UserID,
+	})
+
+	if job.MaxApplications != nil && r.s.countApplications(job.ID) >= int64(*job.MaxApplications) {
+		return r.s.closeJob(job, models.JobCloseReasonFilled, time.Now()), nil
+	}
+	return false, nil
+}
+
+func (s *Store) countApplications(jobID uint) int64 {
+	var count int64
+	for _, application := range s.applications {
+		if application.JobID == jobID {
+			count++
+		}
+	}
+	return count
+}
+
+func (s *Store) addEvent(event models.ApplicationStatusEvent) {
+	event.ID = s.id("application_status_events")
+	event.CreatedAt = now()
+	s.events = append(s.events, event)
+}
+
+func (r *applicationRepository) ChangeStatus(ctx context.Context, application *models.JobApplication, status string, changedBy uint, note string) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	stored, ok := r.s.applications[application.ID]
+	if !ok || stored.Status != application.Status {
+		return repository.ErrStatusChanged
+	}
+	stored.Status = status
+	stored.UpdatedAt = now()
+	r.s.applications[application.ID] = stored
+	r.s.addEvent(models.ApplicationStatusEvent{
+		ApplicationID: application.ID,
+		FromStatus:    application.Status,
+		ToStatus:      status,
+		ChangedByID:   changedBy,
+		Note:          note,
+	})
+
+	application.Status = status
+	return nil
+}
+
+func (r *applicationRepository) History(ctx context.Context, applicationID uint) ([]models.ApplicationStatusEvent, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	// Events are appended in order
+	var events []models.ApplicationStatusEvent
+	for _, event := range r.s.events {
+		if event.ApplicationID == applicationID {
+			event.ChangedBy = r.s.user(event.ChangedByID)
+			events = append(events, event)
+		}
+	}
+	return events, nil
+}
+
+func (r *applicationRepository) UpdateMessage(ctx context.Context, application *models.JobApplication, message string) (bool, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	stored, ok := r.s.applications[application.ID]
+	if !ok || stored.Status != models.ApplicationStatusApplied {
+		return false, nil
+	}
+	stored.Message = message
+	stored.UpdatedAt = now()
+	r.s.applications[application.ID] = stored
+
+	application.Message = message
+	return true, nil
+}
+
+func (r *applicationRepository) ListMessages(ctx context.Context, applicationID uint, offset, limit int) ([]models.ApplicationMessage, int64, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	// Newest first: messages are appended in order
+	var list []models.ApplicationMessage
+	for i := len(r.s.messages) - 1; i >= 0; i-- {
+		if message := r.s.messages[i]; message.ApplicationID == applicationID {
+			message.Sender = r.s.user(message.SenderID)
+			list = append(list, message)
+		}
+	}
+
+	total := int64(len(list))
+	if offset < 0 || offset > len(list) {
+		offset = len(list)
+	}
+	list = list[offset:]
+	if limit >= 0 && len(list) > limit {
+		list = list[:limit]
+	}
+	return list, total, nil
+}
+
+func (r *applicationRepository) CreateMessage(ctx context.Context, message *models.ApplicationMessage) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	message.ID = r.s.id("application_messages")
+	message.CreatedAt = now()
+	for i := range message.Attachments {
+		message.Attachments[i].ID = r.s.id("message_attachments")
+		message.Attachments[i].MessageID = message.ID
+	}
+	message.Sender = r.s.user(message.SenderID)
+
+	stored := *message
+	stored.Attachments = append([]models.MessageAttachment(nil), message.Attachments...)
+	r.s.messages = append(r.s.messages, stored)
+	return nil
+}
+
+func (r *applicationRepository) CountUnread(ctx context.Context, application *models.JobApplication, readerID uint) (int64, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	var unread int64
+	for _, message := range r.s.messages {
+		if isUnreadFor(message, application, readerID) {
+			unread++
+		}
+	}
+	return unread, nil
+}
+
+func (r *applicationRepository) MarkRead(ctx context.Context, application *models.JobApplication, readerID uint, at time.Time) (int64, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	var updated int64
+	for i, message := range r.s.messages {
+		if isUnreadFor(message, application, readerID) {
+			readAt := at
+			r.s.messages[i].ReadAt = &readAt
+			updated++
+		}
+	}
+	return updated, nil
+}
+
+// isUnreadFor reports whether the message is an unread one from the other
+// side of the conversation, see repository.ApplicationRepository.CountUnread.
+func isUnreadFor(message models.ApplicationMessage, application *models.JobApplication, readerID uint) bool {
+	if message.ApplicationID != application.ID || message.ReadAt != nil {
+		return false
+	}
+	if application.UserID == readerID {
+		return message.SenderID != application.UserID
+	}
+	return message.SenderID == application.UserID
+}
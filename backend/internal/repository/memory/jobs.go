@@ -0,0 +1,532 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"job-search-backend/internal/geo"
+	"job-search-backend/internal/jobfilter"
+	"job-search-backend/internal/models"
+	"job-search-backend/internal/pagination"
+	"job-search-backend/internal/repository"
+	"job-search-backend/internal/utils"
+)
+
+type jobRepository struct {
+	s *Store
+}
+
+// job returns a stored job that has not been deleted.
+func (s *Store) job(id uint) (models.Job, bool) {
+	job, ok := s.jobs[id]
+	if !ok || job.DeletedAt.Valid {
+		return models.Job{}, false
+	}
+	return job, true
+}
+
+// loaded fills the fields GORM computes when loading a job.
+func loaded(job models.Job) models.Job {
+	job.Salary = utils.FormatSalary(job.SalaryMin, job.SalaryMax, job.Currency, job.SalaryPeriod)
+	return job
+}
+
+func (r *jobRepository) FindByID(ctx context.Context, id uint) (*models.Job, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	job, ok := r.s.job(id)
+	if !ok {
+		return nil, repository.ErrNotFound
+	}
+	job = loaded(job)
+	return &job, nil
+}
+
+func (r *jobRepository) FindWithDetails(ctx context.Context, id uint) (*models.Job, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	job, ok := r.s.job(id)
+	if !ok {
+		return nil, repository.ErrNotFound
+	}
+	job = loaded(job)
+	job.Employer = r.s.user(job.EmployerID)
+	if job.CompanyID != nil {
+		if company, ok := r.s.companies[*job.CompanyID]; ok {
+			job.CompanyProfile = &company
+		}
+	}
+	return &job, nil
+}
+
+func (r *jobRepository) FindIncludingDeleted(ctx context.Context, id uint) (*models.Job, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	job, ok := r.s.jobs[id]
+	if !ok {
+		return nil, repository.ErrNotFound
+	}
+	job = loaded(job)
+	return &job, nil
+}
+
+func (r *jobRepository) List(ctx context.Context, q repository.JobQuery) ([]models.Job, int64, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	jobs := r.s.matchingJobs(q.Filter, q.ActiveOnly)
+	sortJobs(jobs, q.Order.Name)
+
+	start := q.Page.Offset
+	if q.Page.Cursor != "" {
+		start = -1
+		for i, job := range jobs {
+			if repository.JobCursor(q.Order, job) == q.Page.Cursor {
+				start = i + 1
+				break
+			}
+		}
+		// Unlike keyset pagination, the fake needs the row the cursor was made from
+		if start < 0 {
+			return nil, 0, pagination.ErrInvalidCursor
+		}
+	}
+
+	total := int64(len(jobs))
+	if start > len(jobs) {
+		start = len(jobs)
+	}
+	jobs = jobs[start:]
+	if len(jobs) > q.Page.Limit+1 {
+		jobs = jobs[:q.Page.Limit+1]
+	}
+	for i := range jobs {
+		jobs[i].Employer = r.s.user(jobs[i].EmployerID)
+	}
+	return jobs, total, nil
+}
+
+// matchingJobs returns the jobs matching the filter. Radius searches fill
+// their distances.
+func (s *Store) matchingJobs(filter jobfilter.Filter, activeOnly bool) []models.Job {
+	var jobs []models.Job
+	for id := range s.jobs {
+		job, ok := s.job(id)
+		if !ok || (activeOnly && !job.IsActive) || !matches(filter, &job) {
+			continue
+		}
+		jobs = append(jobs, loaded(job))
+	}
+	return jobs
+}
+
+// matches mirrors jobfilter.Filter.Apply.
+func matches(f jobfilter.Filter, job *models.Job) bool {
+	if f.Category != "" && job.Category != f.Category {
+		return false
+	}
+	if f.Location != "" && !strings.Contains(strings.ToLower(job.Location), strings.ToLower(f.Location)) {
+		return false
+	}
+	if f.Type != "" && job.Type != f.Type {
+		return false
+	}
+	if f.Search != "" {
+		text := strings.ToLower(strings.Join([]string{job.Title, job.Company, job.Description, job.Requirements}, " "))
+		for _, word := range strings.Fields(strings.ToLower(f.Search)) {
+			if !strings.Contains(text, word) {
+				return false
+			}
+		}
+	}
+	if f.SalaryMin != nil {
+		if salary := firstSalary(job.SalaryMax, job.SalaryMin); salary == nil || *salary < *f.SalaryMin {
+			return false
+		}
+	}
+	if f.SalaryMax != nil {
+		if salary := firstSalary(job.SalaryMin, job.SalaryMax); salary == nil || *salary > *f.SalaryMax {
+			return false
+		}
+	}
+	if f.Currency != "" && job.Currency != f.Currency {
+		return false
+	}
+	if f.SalaryPeriod != "" && job.SalaryPeriod != f.SalaryPeriod {
+		return false
+	}
+	if f.WorkMode != "" && job.WorkMode != f.WorkMode {
+		return false
+	}
+	if f.Country != "" && job.Country != f.Country {
+		return false
+	}
+	if f.HasPoint() {
+		if job.Latitude == nil || job.Longitude == nil {
+			return false
+		}
+		distance := geo.DistanceKm(*f.Latitude, *f.Longitude, *job.Latitude, *job.Longitude)
+		if distance > *f.RadiusKm {
+			return false
+		}
+		job.Distance = &distance
+	}
+	return true
+}
+
+func firstSalary(a, b *int) *int {
+	if a != nil {
+		return a
+	}
+	return b
+}
+
+// sortJobs sorts like the job order with the given name; relevance, which
+// needs the full-text rank, sorts like newest.
+func sortJobs(jobs []models.Job, order string) {
+	sort.Slice(jobs, func(i, j int) bool {
+		a, b := jobs[i], jobs[j]
+		switch order {
+		case "salary_desc":
+			if less, decided := compareNullable(firstSalary(b.SalaryMax, b.SalaryMin), firstSalary(a.SalaryMax, a.SalaryMin), true); decided {
+				return less
+			}
+			return a.ID > b.ID
+		case "salary_asc":
+			if less, decided := compareNullable(firstSalary(a.SalaryMin, a.SalaryMax), firstSalary(b.SalaryMin, b.SalaryMax), false); decided {
+				return less
+			}
+			return a.ID < b.ID
+		case "title":
+			if a.Title != b.Title {
+				return a.Title < b.Title
+			}
+			return a.ID < b.ID
+		case "distance":
+			if a.Distance != nil && b.Distance != nil && *a.Distance != *b.Distance {
+				return *a.Distance < *b.Distance
+			}
+			return a.ID < b.ID
+		default:
+			if !a.CreatedAt.Equal(b.CreatedAt) {
+				return a.CreatedAt.After(b.CreatedAt)
+			}
+			return a.ID > b.ID
+		}
+	})
+}
+
+// compareNullable orders x before y when x < y, with NULLs last. swapped
+// means the arguments are in descending order, which must not move NULLs
+// first.
+func compareNullable(x, y *int, swapped bool) (less, decided bool) {
+	switch {
+	case x == nil && y == nil:
+		return false, false
+	case x == nil:
+		return swapped, true
+	case y == nil:
+		return !swapped, true
+	case *x != *y:
+		return *x < *y, true
+	}
+	return false, false
+}
+
+func (r *jobRepository) Facets(ctx context.Context, filter jobfilter.Filter, currency, period string, thresholds []int) (repository.JobFacets, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	facets := repository.JobFacets{
+		Categories:   map[string]int64{},
+		Types:        map[string]int64{},
+		Locations:    map[string]int64{},
+		WorkModes:    map[string]int64{},
+		SalaryCounts: make([]int64, len(thresholds)),
+	}
+	for _, job := range r.s.matchingJobs(filter, true) {
+		facets.Total++
+		facets.Categories[job.Category]++
+		facets.Types[job.Type]++
+		facets.Locations[job.Location]++
+		facets.WorkModes[job.WorkMode]++
+
+		salary := firstSalary(job.SalaryMax, job.SalaryMin)
+		if salary == nil {
+			facets.SalaryNotSpecified++
+			continue
+		}
+		for i, threshold := range thresholds {
+			if job.Currency == currency && job.SalaryPeriod == period && *salary >= threshold {
+				facets.SalaryCounts[i]++
+			}
+		}
+	}
+	return facets, nil
+}
+
+func (r *jobRepository) Create(ctx context.Context, job *models.Job) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	// Column defaults
+	if job.WorkMode == "" {
+		job.WorkMode = models.WorkModeOnsite
+	}
+	if job.Currency == "" {
+		job.Currency = utils.DefaultCurrency
+	}
+	if job.SalaryPeriod == "" {
+		job.SalaryPeriod = utils.SalaryPeriodMonth
+	}
+
+	job.ID = r.s.id("jobs")
+	job.CreatedAt, job.UpdatedAt = now(), now()
+	*job = loaded(*job)
+	r.s.jobs[job.ID] = *job
+	return nil
+}
+
+func (r *jobRepository) Update(ctx context.Context, job *models.Job) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	stored, ok := r.s.jobs[job.ID]
+	if !ok {
+		return repository.ErrNotFound
+	}
+	updated := *job
+	updated.IsActive, updated.ClosedAt, updated.CloseReason = stored.IsActive, stored.ClosedAt, stored.CloseReason
+	updated.UpdatedAt = now()
+	r.s.jobs[job.ID] = updated
+
+	job.UpdatedAt = updated.UpdatedAt
+	*job = loaded(*job)
+	return nil
+}
+
+func (r *jobRepository) Delete(ctx context.Context, job *models.Job) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	stored, ok := r.s.jobs[job.ID]
+	if !ok {
+		return nil
+	}
+	stored.DeletedAt.Time, stored.DeletedAt.Valid = now(), true
+	r.s.jobs[job.ID] = stored
+	return nil
+}
+
+func (r *jobRepository) Close(ctx context.Context, job *models.Job, reason string, at time.Time) (bool, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	return r.s.closeJob(job, reason, at), nil
+}
+
+// closeJob mirrors models.Job.Close. The caller holds the lock.
+func (s *Store) closeJob(job *models.Job, reason string, at time.Time) bool {
+	stored, ok := s.job(job.ID)
+	if !ok || stored.ClosedAt != nil {
+		return false
+	}
+	stored.IsActive, stored.ClosedAt, stored.CloseReason = false, &at, reason
+	s.jobs[job.ID] = stored
+
+	job.IsActive, job.ClosedAt, job.CloseReason = false, &at, reason
+	return true
+}
+
+func (r *jobRepository) Reopen(ctx context.Context, job *models.Job) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	stored, ok := r.s.job(job.ID)
+	if !ok {
+		return nil
+	}
+	stored.IsActive, stored.ClosedAt, stored.CloseReason = job.IsActive, job.ClosedAt, job.CloseReason
+	stored.PublishAt, stored.ExpiresAt, stored.MaxApplications = job.PublishAt, job.ExpiresAt, job.MaxApplications
+	stored.UpdatedAt = now()
+	r.s.jobs[job.ID] = stored
+	return nil
+}
+
+func (r *jobRepository) CountApplications(ctx context.Context, jobID uint) (int64, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	return r.s.countApplications(jobID), nil
+}
+
+func (r *jobRepository) FindCompany(ctx context.Context, id uint) (*models.Company, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	company, ok := r.s.companies[id]
+	if !ok {
+		return nil, repository.ErrNotFound
+	}
+	return &company, nil
+}
+
+func (r *jobRepository) IsCompanyMember(ctx context.Context, companyID, userID uint, roles ...string) (bool, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	return r.s.isCompanyMember(companyID, userID, roles...), nil
+}
+
+func (s *Store) isCompanyMember(companyID, userID uint, roles ...string) bool {
+	for _, member := range s.members {
+		if member.CompanyID != companyID || member.UserID != userID {
+			continue
+		}
+		if len(roles) == 0 {
+			return true
+		}
+		for _, role := range roles {
+			if member.Role == role {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (r *jobRepository) CompanyMemberIDs(ctx context.Context, companyID uint) ([]uint, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	var ids []uint
+	for _, member := range r.s.members {
+		if member.CompanyID == companyID {
+			ids = append(ids, member.UserID)
+		}
+	}
+	return ids, nil
+}
+
+func (r *jobRepository) RemoveCompanyMember(ctx context.Context, companyID, userID uint) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	index := -1
+	owners := 0
+	for i, member := range r.s.members {
+		if member.CompanyID != companyID {
+			continue
+		}
+		if member.Role == models.CompanyRoleOwner {
+			owners++
+		}
+		if member.UserID == userID {
+			index = i
+		}
+	}
+	if index < 0 {
+		return repository.ErrNotFound
+	}
+	if r.s.members[index].Role == models.CompanyRoleOwner && owners <= 1 {
+		return repository.ErrLastOwner
+	}
+	r.s.members = append(r.s.members[:index], r.s.members[index+1:]...)
+	return nil
+}
+
+func (r *jobRepository) CategoryExists(ctx context.Context, slug string) (bool, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	return r.s.categories[slug], nil
+}
+
+func (r *jobRepository) EmploymentTypeExists(ctx context.Context, slug string) (bool, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	return r.s.employmentTypes[slug], nil
+}
+
+func (r *jobRepository) SaveJob(ctx context.Context, userID, jobID uint) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	for _, saved := range r.s.savedJobs {
+		if saved.UserID == userID && saved.JobID == jobID {
+			return nil
+		}
+	}
+	r.s.savedJobs = append(r.s.savedJobs, models.SavedJob{
+		ID:        r.s.id("saved_jobs"),
+		UserID:    userID,
+		JobID:     jobID,
+		CreatedAt: now(),
+	})
+	return nil
+}
+
+func (r *jobRepository) UnsaveJob(ctx context.Context, userID, jobID uint) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	kept := r.s.savedJobs[:0]
+	for _, saved := range r.s.savedJobs {
+		if saved.UserID != userID || saved.JobID != jobID {
+			kept = append(kept, saved)
+		}
+	}
+	r.s.savedJobs = kept
+	return nil
+}
+
+func (r *jobRepository) ListSaved(ctx context.Context, userID uint, offset, limit int) ([]models.SavedJob, int64, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	var list []models.SavedJob
+	for _, saved := range r.s.savedJobs {
+		job, ok := r.s.job(saved.JobID)
+		if saved.UserID != userID || !ok {
+			continue
+		}
+		saved.Job = loaded(job)
+		saved.Job.Employer = r.s.user(job.EmployerID)
+		list = append(list, saved)
+	}
+	sort.SliceStable(list, func(i, j int) bool { return list[i].CreatedAt.After(list[j].CreatedAt) })
+
+	total := int64(len(list))
+	if offset < 0 || offset > len(list) {
+		offset = len(list)
+	}
+	list = list[offset:]
+	if limit >= 0 && len(list) > limit {
+		list = list[:limit]
+	}
+	return list, total, nil
+}
+
+func (r *jobRepository) SavedJobIDs(ctx context.Context, userID uint, jobIDs []uint) ([]uint, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	var ids []uint
+	for _, saved := range r.s.savedJobs {
+		if saved.UserID != userID {
+			continue
+		}
+		for _, id := range jobIDs {
+			if saved.JobID == id {
+				ids = append(ids, id)
+			}
+		}
+	}
+	return ids, nil
+}
@@ -0,0 +1,117 @@
+// Package memory implements the repositories in memory, for tests that
+// exercise handlers without a database.
+//
+// The fakes follow the GORM implementations closely enough for the handlers:
+// they assign IDs and timestamps, soft-delete jobs, load the same associations
+// and report the same errors. They do not run SQL, so full-text search matches
+// plain substrings, relevance sorts like newest and categories have no
+// subcategories.
+package memory
+
+import (
+	"sync"
+	"time"
+
+	"job-search-backend/internal/models"
+	"job-search-backend/internal/repository"
+)
+
+// Store holds the data shared by the repositories it returns. It is safe for
+// concurrent use; every repository call runs under one lock, which also makes
+// the multi-step operations atomic.
+type Store struct {
+	mu     sync.Mutex
+	nextID map[string]uint
+
+	users         map[uint]models.User
+	refreshTokens map[uint]models.RefreshToken
+	actionTokens  map[uint]models.ActionToken
+
+	jobs            map[uint]models.Job
+	companies       map[uint]models.Company
+	members         []models.CompanyMember
+	categories      map[string]bool
+	employmentTypes map[string]bool
+	savedJobs       []models.SavedJob
+
+	applications map[uint]models.JobApplication
+	events       []models.ApplicationStatusEvent
+	messages     []models.ApplicationMessage
+}
+
+func NewStore() *Store {
+	return &Store{
+		nextID:          map[string]uint{},
+		users:           map[uint]models.User{},
+		refreshTokens:   map[uint]models.RefreshToken{},
+		actionTokens:    map[uint]models.ActionToken{},
+		jobs:            map[uint]models.Job{},
+		companies:       map[uint]models.Company{},
+		categories:      map[string]bool{},
+		employmentTypes: map[string]bool{},
+		applications:    map[uint]models.JobApplication{},
+	}
+}
+
+func (s *Store) Jobs() repository.JobRepository {
+	return &jobRepository{s}
+}
+
+func (s *Store) Applications() repository.ApplicationRepository {
+	return &applicationRepository{s}
+}
+
+func (s *Store) Users() repository.UserRepository {
+	return &userRepository{s}
+}
+
+// AddCompany stores a company with the given members.
+func (s *Store) AddCompany(company *models.Company, members ...models.CompanyMember) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	company.ID = s.id("companies")
+	company.CreatedAt, company.UpdatedAt = now(), now()
+	s.companies[company.ID] = *company
+	for _, member := range members {
+		member.ID = s.id("company_members")
+		member.CompanyID = company.ID
+		member.CreatedAt = now()
+		s.members = append(s.members, member)
+	}
+}
+
+// AddCategories makes the slugs valid job categories.
+func (s *Store) AddCategories(slugs ...string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, slug := range slugs {
+		s.categories[slug] = true
+	}
+}
+
+// AddEmploymentTypes makes the slugs valid employment types.
+func (s *Store) AddEmploymentTypes(slugs ...string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, slug := range slugs {
+		s.employmentTypes[slug] = true
+	}
+}
+
+// id returns the next ID of the table. The caller holds the lock.
+func (s *Store) id(table string) uint {
+	s.nextID[table]++
+	return s.nextID[table]
+}
+
+// now is the timestamp the fakes store, rounded like PostgreSQL's
+// microsecond timestamps.
+func now() time.Time {
+	return time.Now().Round(time.Microsecond)
+}
+
+// user returns the stored user, or the zero user like a failed preload.
+func (s *Store) user(id uint) models.User {
+	return s.users[id]
+}
@@ -0,0 +1,204 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"job-search-backend/internal/models"
+	"job-search-backend/internal/repository"
+)
+
+var errDuplicateEmail = errors.New("duplicate key value violates unique constraint on users.email")
+
+type userRepository struct {
+	s *Store
+}
+
+func (r *userRepository) FindByID(ctx context.Context, id uint) (*models.User, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	user, ok := r.s.users[id]
+	if !ok {
+		return nil, repository.ErrNotFound
+	}
+	return &user, nil
+}
+
+func (r *userRepository) FindByEmail(ctx context.Context, email string) (*models.User, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	for _, user := range r.s.users {
+		if user.Email == email {
+			return &user, nil
+		}
+	}
+	return nil, repository.ErrNotFound
+}
+
+func (r *userRepository) Create(ctx context.Context, user *models.User) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	for _, existing := range r.s.users {
+		if existing.Email == user.Email {
+			return errDuplicateEmail
+		}
+	}
+	if user.Role == "" {
+		user.Role = models.RoleJobSeeker
+	}
+	user.ID = r.s.id("users")
+	user.CreatedAt, user.UpdatedAt = now(), now()
+	r.s.users[user.ID] = *user
+	return nil
+}
+
+func (r *userRepository) CreateRefreshToken(ctx context.Context, token *models.RefreshToken) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	r.s.createRefreshToken(token)
+	return nil
+}
+
+func (s *Store) createRefreshToken(token *models.RefreshToken) {
+	token.ID = s.id("refresh_tokens")
+	token.CreatedAt = now()
+	s.refreshTokens[token.ID] = *token
+}
+
+func (r *userRepository) FindRefreshToken(ctx context.Context, tokenHash string) (*models.RefreshToken, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	for _, token := range r.s.refreshTokens {
+		if token.TokenHash == tokenHash {
+			return &token, nil
+		}
+	}
+	return nil, repository.ErrNotFound
+}
+
+func (r *userRepository) RotateRefreshToken(ctx context.Context, oldID uint, next *models.RefreshToken) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	old, ok := r.s.refreshTokens[oldID]
+	if !ok || old.RevokedAt != nil {
+		return repository.ErrTokenReused
+	}
+	revokedAt := now()
+	old.RevokedAt = &revokedAt
+	r.s.refreshTokens[oldID] = old
+	r.s.createRefreshToken(next)
+	return nil
+}
+
+func (r *userRepository) RevokeSession(ctx context.Context, sessionID string) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	r.s.revokeSessions(func(token models.RefreshToken) bool { return token.SessionID == sessionID })
+	return nil
+}
+
+func (r *userRepository) RevokeUserSessions(ctx context.Context, userID uint) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	r.s.revokeSessions(func(token models.RefreshToken) bool { return token.UserID == userID })
+	return nil
+}
+
+func (r *userRepository) SessionActive(ctx context.Context, userID uint, sessionID string) (bool, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	for _, token := range r.s.refreshTokens {
+		if token.SessionID == sessionID && token.UserID == userID && token.RevokedAt == nil && token.ExpiresAt.After(time.Now()) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (s *Store) revokeSessions(match func(models.RefreshToken) bool) {
+	revokedAt := now()
+	for id, token := range s.refreshTokens {
+		if token.RevokedAt == nil && match(token) {
+			token.RevokedAt = &revokedAt
+			s.refreshTokens[id] = token
+		}
+	}
+}
+
+func (r *userRepository) ReplaceActionToken(ctx context.Context, token *models.ActionToken) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	usedAt := now()
+	for id, existing := range r.s.actionTokens {
+		if existing.UserID == token.UserID && existing.Purpose == token.Purpose && existing.UsedAt == nil {
+			existing.UsedAt = &usedAt
+			r.s.actionTokens[id] = existing
+		}
+	}
+	token.ID = r.s.id("action_tokens")
+	token.CreatedAt = now()
+	r.s.actionTokens[token.ID] = *token
+	return nil
+}
+
+func (r *userRepository) VerifyEmail(ctx context.Context, userID uint, tokenID string) (*models.User, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if err := r.s.consumeActionToken(userID, models.TokenPurposeVerifyEmail, tokenID); err != nil {
+		return nil, err
+	}
+	user, ok := r.s.users[userID]
+	if !ok {
+		return nil, repository.ErrInvalidToken
+	}
+	if user.EmailVerifiedAt == nil {
+		verifiedAt := now()
+		user.EmailVerifiedAt = &verifiedAt
+		r.s.users[userID] = user
+	}
+	return &user, nil
+}
+
+func (r *userRepository) ResetPassword(ctx context.Context, userID uint, tokenID string, passwordHash string) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if err := r.s.consumeActionToken(userID, models.TokenPurposePasswordReset, tokenID); err != nil {
+		return err
+	}
+	if user, ok := r.s.users[userID]; ok {
+		user.Password = passwordHash
+		if user.EmailVerifiedAt == nil {
+			verifiedAt := now()
+			user.EmailVerifiedAt = &verifiedAt
+		}
+		r.s.users[userID] = user
+	}
+	r.s.revokeSessions(func(token models.RefreshToken) bool { return token.UserID == userID })
+	return nil
+}
+
+func (s *Store) consumeActionToken(userID uint, purpose, tokenID string) error {
+	for id, token := range s.actionTokens {
+		if token.TokenID == tokenID && token.UserID == userID && token.Purpose == purpose &&
+			token.UsedAt == nil && token.ExpiresAt.After(time.Now()) {
+			usedAt := now()
+			token.UsedAt = &usedAt
+			s.actionTokens[id] = token
+			return nil
+		}
+	}
+	return repository.ErrInvalidToken
+}
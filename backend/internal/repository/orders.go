@@ -0,0 +1,90 @@
+package repository
+
+import (
+	"job-search-backend/internal/jobfilter"
+	"job-search-backend/internal/models"
+	"job-search-backend/internal/pagination"
+)
+
+// jobOrders are the sort orders of job lists. relevance is only available
+// when searching and distance for radius searches.
+var jobOrders = map[string]pagination.Order{
+	"newest": {Name: "newest", Keys: []pagination.Key{
+		{Expr: "jobs.created_at", Kind: pagination.Time, Desc: true},
+		{Expr: "jobs.id", Kind: pagination.Int, Desc: true},
+	}},
+	"salary_desc": {Name: "salary_desc", Keys: []pagination.Key{
+		{Expr: "COALESCE(jobs.salary_max, jobs.salary_min)", Kind: pagination.Int, Desc: true, Nullable: true},
+		{Expr: "jobs.id", Kind: pagination.Int, Desc: true},
+	}},
+	"salary_asc": {Name: "salary_asc", Keys: []pagination.Key{
+		{Expr: "COALESCE(jobs.salary_min, jobs.salary_max)", Kind: pagination.Int, Nullable: true},
+		{Expr: "jobs.id", Kind: pagination.Int},
+	}},
+	"title": {Name: "title", Keys: []pagination.Key{
+		{Expr: "jobs.title", Kind: pagination.String},
+		{Expr: "jobs.id", Kind: pagination.Int},
+	}},
+}
+
+// JobOrder returns the job sort order with the given name for the filter.
+func JobOrder(name string, filter jobfilter.Filter) (pagination.Order, bool) {
+	switch {
+	case name == "relevance" && filter.Search != "":
+		searchExpr, searchArgs := filter.SearchQuery()
+		return pagination.Order{Name: "relevance", Keys: []pagination.Key{
+			{Expr: "ts_rank_cd(jobs.search_vector, " + searchExpr + ")", Args: searchArgs, Kind: pagination.Float, Desc: true},
+			{Expr: "jobs.id", Kind: pagination.Int, Desc: true},
+		}}, true
+	case name == "distance" && filter.HasPoint():
+		distanceExpr, distanceArgs := filter.DistanceExpr()
+		return pagination.Order{Name: "distance", Keys: []pagination.Key{
+			{Expr: distanceExpr, Args: distanceArgs, Kind: pagination.Float, Nullable: true},
+			{Expr: "jobs.id", Kind: pagination.Int},
+		}}, true
+	}
+	order, ok := jobOrders[name]
+	return order, ok
+}
+
+// JobCursor returns the cursor of the page that follows the job.
+func JobCursor(order pagination.Order, job models.Job) string {
+	return order.Cursor(jobCursorValues(order.Name, job)...)
+}
+
+// jobCursorValues returns the sort key values of a job for the given order.
+func jobCursorValues(order string, job models.Job) []interface{} {
+	switch order {
+	case "salary_desc":
+		return []interface{}{firstSalary(job.SalaryMax, job.SalaryMin), job.ID}
+	case "salary_asc":
+		return []interface{}{firstSalary(job.SalaryMin, job.SalaryMax), job.ID}
+	case "title":
+		return []interface{}{job.Title, job.ID}
+	case "relevance":
+		return []interface{}{job.SearchRank, job.ID}
+	case "distance":
+		return []interface{}{job.Distance, job.ID}
+	default:
+		return []interface{}{job.CreatedAt, job.ID}
+	}
+}
+
+// firstSalary mirrors COALESCE over two salary bounds.
+func firstSalary(a, b *int) *int {
+	if a != nil {
+		return a
+	}
+	return b
+}
+
+// ApplicationOrder lists applications newest first.
+var ApplicationOrder = pagination.Order{Name: "newest", Keys: []pagination.Key{
+	{Expr: "job_applications.created_at", Kind: pagination.Time, Desc: true},
+	{Expr: "job_applications.id", Kind: pagination.Int, Desc: true},
+}}
+
+// ApplicationCursor returns the cursor of the page that follows the application.
+func ApplicationCursor(application models.JobApplication) string {
+	return ApplicationOrder.Cursor(application.CreatedAt, application.ID)
+}
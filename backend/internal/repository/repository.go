@@ -0,0 +1,169 @@
+// Package repository is the persistence layer behind the job, application and
+// auth handlers. Each repository is an interface with a GORM implementation;
+// package memory provides in-memory fakes so that handlers can be tested
+// without a database.
+//
+// Operations that must be atomic are single repository methods, each of which
+// runs in one transaction.
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"job-search-backend/internal/jobfilter"
+	"job-search-backend/internal/models"
+	"job-search-backend/internal/pagination"
+)
+
+var (
+	ErrNotFound       = errors.New("record not found")
+	ErrAlreadyApplied = errors.New("already applied for this job")
+	ErrJobClosed      = errors.New("job is not accepting applications")
+	ErrStatusChanged  = errors.New("application status changed concurrently")
+	ErrTokenReused    = errors.New("refresh token reused")
+	ErrInvalidToken   = errors.New("invalid or expired token")
+	ErrLastOwner      = errors.New("cannot remove the last owner of the company")
+)
+
+// JobQuery selects a page of jobs.
+type JobQuery struct {
+	Filter     jobfilter.Filter
+	ActiveOnly bool
+	Order      pagination.Order // see JobOrder
+	Page       pagination.Page
+}
+
+// JobFacets holds the number of jobs per value of each facet.
+type JobFacets struct {
+	Total      int64
+	Categories map[string]int64
+	Types      map[string]int64
+	Locations  map[string]int64
+	WorkModes  map[string]int64
+
+	// SalaryCounts[i] is the number of jobs paying at least the i-th
+	// threshold in the requested currency.
+	SalaryCounts       []int64
+	SalaryNotSpecified int64
+}
+
+type JobRepository interface {
+	// FindByID returns the job without its associations.
+	FindByID(ctx context.Context, id uint) (*models.Job, error)
+	// FindWithDetails returns the job with its employer and company profile.
+	FindWithDetails(ctx context.Context, id uint) (*models.Job, error)
+	// FindIncludingDeleted is FindByID that also finds deleted jobs.
+	FindIncludingDeleted(ctx context.Context, id uint) (*models.Job, error)
+	// List returns up to Page.Limit+1 matching jobs with their employers, so
+	// that the caller can tell whether there is a next page, and the total
+	// number of matching jobs.
+	List(ctx context.Context, query JobQuery) ([]models.Job, int64, error)
+	// Facets counts the active jobs matching the filter per facet value.
+	// Salary thresholds are amounts in currency per period.
+	Facets(ctx context.Context, filter jobfilter.Filter, currency, period string, thresholds []int) (JobFacets, error)
+
+	Create(ctx context.Context, job *models.Job) error
+	// Update saves the job except its open/closed state, which only changes
+	// through Close and Reopen.
+	Update(ctx context.Context, job *models.Job) error
+	Delete(ctx context.Context, job *models.Job) error
+	// Close deactivates the job unless it has already been closed and reports
+	// whether this call closed it.
+	Close(ctx context.Context, job *models.Job, reason string, now time.Time) (bool, error)
+	// Reopen saves the open state, schedule and application cap of the job.
+	Reopen(ctx context.Context, job *models.Job) error
+	CountApplications(ctx context.Context, jobID uint) (int64, error)
+
+	FindCompany(ctx context.Context, id uint) (*models.Company, error)
+	// IsCompanyMember reports whether the user belongs to the company,
+	// optionally with one of the given company roles.
+	IsCompanyMember(ctx context.Context, companyID, userID uint, roles ...string) (bool, error)
+	// CompanyMemberIDs returns the ids of the company's members.
+	CompanyMemberIDs(ctx context.Context, companyID uint) ([]uint, error)
+	// RemoveCompanyMember deletes the user's membership. It fails with
+	// ErrNotFound, or ErrLastOwner when the user is the company's only owner.
+	RemoveCompanyMember(ctx context.Context, companyID, userID uint) error
+	CategoryExists(ctx context.Context, slug string) (bool, error)
+	EmploymentTypeExists(ctx context.Context, slug string) (bool, error)
+
+	// SaveJob bookmarks the job for the user; saving it twice is a no-op.
+	SaveJob(ctx context.Context, userID, jobID uint) error
+	UnsaveJob(ctx context.Context, userID, jobID uint) error
+	// ListSaved returns the user's bookmarks of jobs that still exist, most
+	// recently saved first, and their total number.
+	ListSaved(ctx context.Context, userID uint, offset, limit int) ([]models.SavedJob, int64, error)
+	// SavedJobIDs returns which of the jobs the user has saved.
+	SavedJobIDs(ctx context.Context, userID uint, jobIDs []uint) ([]uint, error)
+}
+
+// ApplicationQuery selects a page of applications. UserID, ManagerID and
+// JobID are alternatives; without any of them every application is listed.
+type ApplicationQuery struct {
+	UserID    uint // sent by this applicant, with their jobs and employers
+	ManagerID uint // to the jobs this user manages (see ManagedJobsScope), with jobs and applicants
+	JobID     uint // to this job, with applicants and their profiles
+	Status    string
+	Page      pagination.Page
+}
+
+type ApplicationRepository interface {
+	// FindByID returns the application with its job.
+	FindByID(ctx context.Context, id uint) (*models.JobApplication, error)
+	// List returns up to Page.Limit+1 applications, newest first.
+	List(ctx context.Context, query ApplicationQuery) ([]models.JobApplication, error)
+	// Apply stores a new application together with the first event of its
+	// timeline. The job is locked for the duration and loaded into job; it
+	// is closed when the application fills it, which is reported by filled.
+	// It fails with ErrNotFound, ErrAlreadyApplied or ErrJobClosed.
+	Apply(ctx context.Context, application *models.JobApplication, job *models.Job) (filled bool, err error)
+	// ChangeStatus moves the application to a new status and records the
+	// change in its timeline. It fails with ErrStatusChanged when the status
+	// is no longer the one in application.
+	ChangeStatus(ctx context.Context, application *models.JobApplication, status string, changedBy uint, note string) error
+	// History returns the status timeline with the users who made the changes.
+	History(ctx context.Context, applicationID uint) ([]models.ApplicationStatusEvent, error)
+	// UpdateMessage replaces the cover message while the application is still
+	// pending and reports whether it was.
+	UpdateMessage(ctx context.Context, application *models.JobApplication, message string) (bool, error)
+
+	// ListMessages returns the conversation of an application, newest first,
+	// and its total number of messages.
+	ListMessages(ctx context.Context, applicationID uint, offset, limit int) ([]models.ApplicationMessage, int64, error)
+	// CreateMessage stores the message with its attachments and loads its sender.
+	CreateMessage(ctx context.Context, message *models.ApplicationMessage) error
+	// CountUnread counts the unread messages the reader got from the other
+	// side of the conversation.
+	CountUnread(ctx context.Context, application *models.JobApplication, readerID uint) (int64, error)
+	// MarkRead marks those messages as read and returns how many there were.
+	MarkRead(ctx context.Context, application *models.JobApplication, readerID uint, now time.Time) (int64, error)
+}
+
+type UserRepository interface {
+	FindByID(ctx context.Context, id uint) (*models.User, error)
+	FindByEmail(ctx context.Context, email string) (*models.User, error)
+	Create(ctx context.Context, user *models.User) error
+
+	CreateRefreshToken(ctx context.Context, token *models.RefreshToken) error
+	FindRefreshToken(ctx context.Context, tokenHash string) (*models.RefreshToken, error)
+	// RotateRefreshToken revokes the refresh token oldID and stores next in
+	// its place. It fails with ErrTokenReused when oldID is already revoked.
+	RotateRefreshToken(ctx context.Context, oldID uint, next *models.RefreshToken) error
+	RevokeSession(ctx context.Context, sessionID string) error
+	RevokeUserSessions(ctx context.Context, userID uint) error
+	// SessionActive reports whether the session still has an unrevoked,
+	// unexpired refresh token; access tokens are only valid while it does.
+	SessionActive(ctx context.Context, userID uint, sessionID string) (bool, error)
+
+	// ReplaceActionToken invalidates the user's unused tokens for the same
+	// purpose and stores the new one.
+	ReplaceActionToken(ctx context.Context, token *models.ActionToken) error
+	// VerifyEmail consumes a verify_email token and marks the user's address
+	// as verified. It fails with ErrInvalidToken when the token is unknown,
+	// used or expired.
+	VerifyEmail(ctx context.Context, userID uint, tokenID string) (*models.User, error)
+	// ResetPassword consumes a password_reset token, sets the password hash,
+	// marks the address as verified and revokes every session of the user.
+	ResetPassword(ctx context.Context, userID uint, tokenID string, passwordHash string) error
+}
@@ -0,0 +1,154 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"job-search-backend/internal/models"
+
+	"gorm.io/gorm"
+)
+
+type userRepository struct {
+	db *gorm.DB
+}
+
+// NewUserRepository returns the UserRepository backed by db.
+func NewUserRepository(db *gorm.DB) UserRepository {
+	return &userRepository{db: db}
+}
+
+func (r *userRepository) FindByID(ctx context.Context, id uint) (*models.User, error) {
+	var user models.User
+	if err := r.db.WithContext(ctx).First(&user, id).Error; err != nil {
+		return nil, notFound(err)
+	}
+	return &user, nil
+}
+
+func (r *userRepository) FindByEmail(ctx context.Context, email string) (*models.User, error) {
+	var user models.User
+	if err := r.db.WithContext(ctx).Where("email = ?", email).First(&user).Error; err != nil {
+		return nil, notFound(err)
+	}
+	return &user, nil
+}
+
+func (r *userRepository) Create(ctx context.Context, user *models.User) error {
+	return r.db.WithContext(ctx).Create(user).Error
+}
+
+func (r *userRepository) CreateRefreshToken(ctx context.Context, token *models.RefreshToken) error {
+	return r.db.WithContext(ctx).Create(token).Error
+}
+
+func (r *userRepository) FindRefreshToken(ctx context.Context, tokenHash string) (*models.RefreshToken, error) {
+	var token models.RefreshToken
+	if err := r.db.WithContext(ctx).Where("token_hash = ?", tokenHash).First(&token).Error; err != nil {
+		return nil, notFound(err)
+	}
+	return &token, nil
+}
+
+func (r *userRepository) RotateRefreshToken(ctx context.Context, oldID uint, next *models.RefreshToken) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.RefreshToken{}).
+			Where("id = ? AND revoked_at IS NULL", oldID).
+			Update("revoked_at", time.Now())
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrTokenReused
+		}
+		return tx.Create(next).Error
+	})
+}
+
+func (r *userRepository) RevokeSession(ctx context.Context, sessionID string) error {
+	return RevokeSessions(r.db.WithContext(ctx).Where("session_id = ?", sessionID))
+}
+
+func (r *userRepository) RevokeUserSessions(ctx context.Context, userID uint) error {
+	return RevokeSessions(r.db.WithContext(ctx).Where("user_id = ?", userID))
+}
+
+func (r *userRepository) SessionActive(ctx context.Context, userID uint, sessionID string) (bool, error) {
+	var active int64
+	err := r.db.WithContext(ctx).Model(&models.RefreshToken{}).
+		Where("session_id = ? AND user_id = ? AND revoked_at IS NULL AND expires_at > ?", sessionID, userID, time.Now()).
+		Count(&active).Error
+	return active > 0, err
+}
+
+// RevokeSessions revokes every still-active refresh token matched by scope,
+// which also invalidates the access tokens of those sessions.
+func RevokeSessions(scope *gorm.DB) error {
+	return scope.Model(&models.RefreshToken{}).
+		Where("revoked_at IS NULL").
+		Update("revoked_at", time.Now()).Error
+}
+
+func (r *userRepository) ReplaceActionToken(ctx context.Context, token *models.ActionToken) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.ActionToken{}).
+			Where("user_id = ? AND purpose = ? AND used_at IS NULL", token.UserID, token.Purpose).
+			Update("used_at", time.Now()).Error; err != nil {
+			return err
+		}
+		return tx.Create(token).Error
+	})
+}
+
+func (r *userRepository) VerifyEmail(ctx context.Context, userID uint, tokenID string) (*models.User, error) {
+	var user models.User
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := consumeActionToken(tx, userID, models.TokenPurposeVerifyEmail, tokenID); err != nil {
+			return err
+		}
+		if err := tx.First(&user, userID).Error; err != nil {
+			return ErrInvalidToken
+		}
+		if user.EmailVerifiedAt != nil {
+			return nil
+		}
+		now := time.Now()
+		user.EmailVerifiedAt = &now
+		return tx.Model(&user).Update("email_verified_at", now).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+func (r *userRepository) ResetPassword(ctx context.Context, userID uint, tokenID string, passwordHash string) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := consumeActionToken(tx, userID, models.TokenPurposePasswordReset, tokenID); err != nil {
+			return err
+		}
+		// Following the reset link proves ownership of the address as well
+		if err := tx.Model(&models.User{}).Where("id = ?", userID).Updates(map[string]interface{}{
+			"password":          passwordHash,
+			"email_verified_at": gorm.Expr("COALESCE(email_verified_at, ?)", time.Now()),
+		}).Error; err != nil {
+			return err
+		}
+		return RevokeSessions(tx.Where("user_id = ?", userID))
+	})
+}
+
+// consumeActionToken marks an unused, unexpired token as used. It returns
+// ErrInvalidToken if there is no such token.
+func consumeActionToken(tx *gorm.DB, userID uint, purpose, tokenID string) error {
+	result := tx.Model(&models.ActionToken{}).
+		Where("token_id = ? AND user_id = ? AND purpose = ? AND used_at IS NULL AND expires_at > ?", tokenID, userID, purpose, time.Now()).
+		Update("used_at", time.Now())
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrInvalidToken
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+// Package scheduler publishes and closes jobs according to their publish_at
+// and expires_at times.
+package scheduler
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"job-search-backend/internal/database"
+	"job-search-backend/internal/models"
+	"job-search-backend/internal/notifications"
+)
+
+const DefaultInterval = time.Minute
+
+type Scheduler struct {
+	Notifications *notifications.Service
+	Interval      time.Duration
+}
+
+// Run publishes and expires jobs every Interval until ctx is cancelled.
+func (s *Scheduler) Run(ctx context.Context) {
+	interval := s.Interval
+	if interval <= 0 {
+		interval = DefaultInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := s.RunOnce(ctx, time.Now()); err != nil {
+			log.Println("Job scheduler failed:", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunOnce activates the scheduled jobs whose publish time has come and closes
+// the active jobs that have expired.
+func (s *Scheduler) RunOnce(ctx context.Context, now time.Time) error {
+	db := database.DB.WithContext(ctx)
+
+	result := db.Model(&models.Job{}).
+		Where("is_active = ? AND closed_at IS NULL AND publish_at <= ?", false, now).
+		Where("expires_at IS NULL OR expires_at > ?", now).
+		Update("is_active", true)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected > 0 {
+		log.Printf("Published %d scheduled jobs", result.RowsAffected)
+	}
+
+	var expired []models.Job
+	if err := db.Where("closed_at IS NULL AND expires_at <= ?", now).Find(&expired).Error; err != nil {
+		return err
+	}
+	for i := range expired {
+		job := &expired[i]
+		closed, err := job.Close(db, models.JobCloseReasonExpired, now)
+		if err != nil {
+			return err
+		}
+		if closed && s.Notifications != nil {
+			if err := s.Notifications.NotifyJobClosed(ctx, job); err != nil {
+				log.Printf("Failed to send job %d closed notifications: %v", job.ID, err)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,115 @@
+package server
+
+import (
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"testing"
+
+	"job-search-backend/internal/mail"
+	"job-search-backend/internal/models"
+)
+
+const appURL = "https://jobs.example.com"
+
+var (
+	linkPattern  = regexp.MustCompile(`https?://\S+`)
+	routePattern = regexp.MustCompile(`<Route path="([^"]+)"`)
+)
+
+// frontendRoutes returns the paths routed by the web app.
+func frontendRoutes(t *testing.T) map[string]bool {
+	t.Helper()
+	app, err := os.ReadFile("../../../frontend/src/App.tsx")
+	if err != nil {
+		t.Fatal(err)
+	}
+	routes := map[string]bool{}
+	for _, m := range routePattern.FindAllStringSubmatch(string(app), -1) {
+		routes[m[1]] = true
+	}
+	return routes
+}
+
+// followLink returns the token of the link in the last email sent to the
+// address, checking that the link opens a page of the web app.
+func followLink(t *testing.T, mailer *mail.Memory, to string) string {
+	t.Helper()
+	var body string
+	for _, msg := range mailer.Messages() {
+		if msg.To == to {
+			body = msg.Body
+		}
+	}
+	link, err := url.Parse(linkPattern.FindString(body))
+	if err != nil || link.Host == "" {
+		t.Fatalf("no link in the email to %s: %q", to, body)
+	}
+	if base, _ := url.Parse(appURL); link.Host != base.Host {
+		t.Fatalf("link %s does not point to the web app", link)
+	}
+	if !frontendRoutes(t)[link.Path] {
+		t.Fatalf("link %s opens %s, which the web app does not route", link, link.Path)
+	}
+	token := link.Query().Get("token")
+	if token == "" {
+		t.Fatalf("link %s has no token", link)
+	}
+	return token
+}
+
+func TestEmailVerificationLink(t *testing.T) {
+	mailer := mail.NewMemory()
+	api := newTestAPIWith(t, func(opts *Options) {
+		opts.Mailer = mailer
+		opts.AppURL = appURL
+		opts.RequireVerifiedEmail = map[string]bool{"login": true}
+	})
+
+	credentials := map[string]string{"email": "dev@example.com", "password": "secret1"}
+	body := map[string]string{"email": "dev@example.com", "password": "secret1", "name": "Dev", "role": models.RoleJobSeeker}
+	if code := api.do(http.MethodPost, "/api/auth/register", "", body, nil); code != http.StatusCreated {
+		t.Fatalf("register: got %d", code)
+	}
+	if code := api.do(http.MethodPost, "/api/auth/login", "", credentials, nil); code != http.StatusForbidden {
+		t.Fatalf("login before confirming: got %d, want %d", code, http.StatusForbidden)
+	}
+
+	// The page at the link posts its token back
+	token := followLink(t, mailer, "dev@example.com")
+	if code := api.do(http.MethodPost, "/api/auth/verify-email/confirm", "", map[string]string{"token": token}, nil); code != http.StatusOK {
+		t.Fatalf("confirm: got %d, want %d", code, http.StatusOK)
+	}
+	if code := api.do(http.MethodPost, "/api/auth/verify-email/confirm", "", map[string]string{"token": token}, nil); code != http.StatusBadRequest {
+		t.Fatalf("reusing the link: got %d, want %d", code, http.StatusBadRequest)
+	}
+	if code := api.do(http.MethodPost, "/api/auth/login", "", credentials, nil); code != http.StatusOK {
+		t.Fatalf("login after confirming: got %d, want %d", code, http.StatusOK)
+	}
+}
+
+func TestPasswordResetLink(t *testing.T) {
+	mailer := mail.NewMemory()
+	api := newTestAPIWith(t, func(opts *Options) {
+		opts.Mailer = mailer
+		opts.AppURL = appURL
+	})
+	session := api.register("dev@example.com", models.RoleJobSeeker)
+
+	if code := api.do(http.MethodPost, "/api/auth/password/forgot", "", map[string]string{"email": "dev@example.com"}, nil); code != http.StatusOK {
+		t.Fatalf("forgot: got %d", code)
+	}
+	token := followLink(t, mailer, "dev@example.com")
+
+	reset := map[string]string{"token": token, "password": "secret2"}
+	if code := api.do(http.MethodPost, "/api/auth/password/reset", "", reset, nil); code != http.StatusOK {
+		t.Fatalf("reset: got %d, want %d", code, http.StatusOK)
+	}
+	if code := api.do(http.MethodGet, "/api/applications/my", session, nil, nil); code != http.StatusUnauthorized {
+		t.Fatalf("old session after reset: got %d, want %d", code, http.StatusUnauthorized)
+	}
+	if code := api.do(http.MethodPost, "/api/auth/login", "", map[string]string{"email": "dev@example.com", "password": "secret2"}, nil); code != http.StatusOK {
+		t.Fatalf("login with the new password: got %d, want %d", code, http.StatusOK)
+	}
+}
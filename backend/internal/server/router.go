@@ -0,0 +1,225 @@
+// Package server builds the HTTP API: the gin router with every route,
+// its middlewares and the handlers wired to their dependencies.
+package server
+
+import (
+	"net/http"
+
+	"job-search-backend/internal/handlers"
+	"job-search-backend/internal/mail"
+	"job-search-backend/internal/middleware"
+	"job-search-backend/internal/notifications"
+	"job-search-backend/internal/repository"
+	"job-search-backend/internal/storage"
+
+	"github.com/gin-contrib/cors"
+	"github.com/gin-gonic/gin"
+)
+
+// Options are the dependencies of the router. Jobs, Applications and Users
+// are required; handlers not yet behind a repository use database.DB.
+type Options struct {
+	Jobs          repository.JobRepository
+	Applications  repository.ApplicationRepository
+	Users         repository.UserRepository
+	Mailer        mail.Mailer
+	Storage       storage.Storage
+	Notifications *notifications.Service
+	// Health backs /healthz and /readyz; without it /readyz checks nothing
+	Health *handlers.HealthHandler
+
+	// AppURL is the frontend address used in links sent by email
+	AppURL string
+	// RequireVerifiedEmail lists what unverified accounts may not do: "login", "apply"
+	RequireVerifiedEmail map[string]bool
+	// MaxResumeSize overrides the resume upload limit when positive
+	MaxResumeSize int64
+}
+
+// NewRouter returns the router serving the API under /api.
+func NewRouter(opts Options) *gin.Engine {
+	// Initialize Gin router; probes are not logged as they run every few seconds
+	r := gin.New()
+	r.Use(middleware.StreamToken("/api/notifications/stream"))
+	r.Use(gin.LoggerWithConfig(gin.LoggerConfig{SkipPaths: []string{"/healthz", "/readyz"}}), gin.Recovery())
+
+	// CORS configuration
+	config := cors.DefaultConfig()
+	config.AllowOrigins = []string{"*"}
+	config.AllowMethods = []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS", "TRACE"}
+	config.AllowHeaders = []string{"Origin", "Content-Type", "Accept", "Authorization"}
+	config.AllowCredentials = true
+	r.Use(cors.New(config))
+
+	// Add TRACE method support
+	r.Handle("TRACE", "/*path", func(c *gin.Context) {
+		c.Header("Allow", "GET, POST, PUT, PATCH, DELETE, OPTIONS")
+		c.JSON(http.StatusMethodNotAllowed, gin.H{"error": "Method Not Allowed"})
+	})
+
+	// Initialize handlers
+	authHandler := handlers.NewAuthHandler(opts.Users, opts.Mailer)
+	authHandler.AppURL = opts.AppURL
+	authHandler.RequireVerifiedEmail = opts.RequireVerifiedEmail["login"]
+	jobHandler := handlers.NewJobHandler(opts.Jobs, opts.Notifications)
+	applicationHandler := handlers.NewApplicationHandler(opts.Applications, opts.Jobs, opts.Users, opts.Notifications)
+	applicationHandler.RequireVerifiedEmail = opts.RequireVerifiedEmail["apply"]
+	notificationHandler := &handlers.NotificationHandler{Service: opts.Notifications, Users: opts.Users}
+	interviewHandler := &handlers.InterviewHandler{
+		Jobs:          opts.Jobs,
+		Applications:  opts.Applications,
+		Users:         opts.Users,
+		Notifications: opts.Notifications,
+	}
+	profileHandler := &handlers.ProfileHandler{}
+	adminHandler := &handlers.AdminHandler{}
+	companyHandler := &handlers.CompanyHandler{Jobs: opts.Jobs}
+	savedSearchHandler := &handlers.SavedSearchHandler{Jobs: opts.Jobs}
+	referenceHandler := &handlers.ReferenceHandler{}
+	resumeHandler := &handlers.ResumeHandler{Storage: opts.Storage}
+	if opts.MaxResumeSize > 0 {
+		resumeHandler.MaxSize = opts.MaxResumeSize
+	}
+
+	// Health probes
+	healthHandler := opts.Health
+	if healthHandler == nil {
+		healthHandler = &handlers.HealthHandler{}
+	}
+	r.GET("/healthz", healthHandler.Live)
+	r.GET("/readyz", healthHandler.Ready)
+
+	// Public routes
+	api := r.Group("/api")
+	{
+		// Auth routes
+		auth := api.Group("/auth")
+		{
+			auth.POST("/register", authHandler.Register)
+			auth.POST("/login", authHandler.Login)
+			auth.POST("/refresh", authHandler.Refresh)
+			auth.POST("/verify-email/request", authHandler.RequestEmailVerification)
+			auth.POST("/verify-email/confirm", authHandler.ConfirmEmail)
+			auth.POST("/password/forgot", authHandler.ForgotPassword)
+			auth.POST("/password/reset", authHandler.ResetPassword)
+		}
+
+		// Public job routes
+		jobs := api.Group("/jobs", middleware.OptionalAuthMiddleware(opts.Users))
+		{
+			jobs.GET("", jobHandler.GetJobs)
+			jobs.GET("/facets", jobHandler.GetJobFacets)
+			jobs.GET("/:id", jobHandler.GetJob)
+		}
+
+		// Public company pages
+		companies := api.Group("/companies")
+		{
+			companies.GET("", companyHandler.GetCompanies)
+			companies.GET("/:id", companyHandler.GetCompany)
+		}
+
+		// Reference data
+		api.GET("/categories", referenceHandler.ListCategories)
+		api.GET("/employment-types", referenceHandler.ListEmploymentTypes)
+
+		// Live notification stream; StreamToken takes its token from the query
+		api.GET("/notifications/stream", middleware.AuthMiddleware(opts.Users), notificationHandler.Stream)
+	}
+
+	// Protected routes
+	protected := api.Group("")
+	protected.Use(middleware.AuthMiddleware(opts.Users))
+	{
+		// Sessions
+		protected.POST("/auth/logout", authHandler.Logout)
+		protected.POST("/auth/logout-all", authHandler.LogoutAll)
+
+		// User profile
+		protected.GET("/profile", profileHandler.GetProfile)
+		protected.PUT("/profile", profileHandler.ReplaceProfile)
+		protected.PATCH("/profile", profileHandler.PatchProfile)
+
+		// Resume files
+		protected.POST("/profile/resume", resumeHandler.UploadResume)
+		protected.GET("/profile/resume", resumeHandler.DownloadOwnResume)
+		protected.DELETE("/profile/resume", resumeHandler.DeleteResume)
+		protected.GET("/users/:id/resume", resumeHandler.DownloadResume)
+
+		// Job management (employers)
+		protected.POST("/jobs", middleware.EmployerMiddleware(), jobHandler.CreateJob)
+		protected.PUT("/jobs/:id", jobHandler.UpdateJob)
+		protected.DELETE("/jobs/:id", jobHandler.DeleteJob)
+		protected.POST("/jobs/:id/close", jobHandler.CloseJob)
+		protected.POST("/jobs/:id/reopen", jobHandler.ReopenJob)
+
+		// Saved jobs
+		protected.GET("/jobs/saved", jobHandler.GetSavedJobs)
+		protected.POST("/jobs/:id/save", jobHandler.SaveJob)
+		protected.DELETE("/jobs/:id/save", jobHandler.UnsaveJob)
+
+		// Notifications
+		protected.GET("/notifications", notificationHandler.GetNotifications)
+		protected.GET("/notifications/unread-count", notificationHandler.GetUnreadCount)
+		protected.POST("/notifications/:id/read", notificationHandler.MarkRead)
+		protected.POST("/notifications/read-all", notificationHandler.MarkAllRead)
+
+		// Saved searches
+		protected.GET("/saved-searches", savedSearchHandler.GetSavedSearches)
+		protected.POST("/saved-searches", savedSearchHandler.CreateSavedSearch)
+		protected.PUT("/saved-searches/:id", savedSearchHandler.UpdateSavedSearch)
+		protected.DELETE("/saved-searches/:id", savedSearchHandler.DeleteSavedSearch)
+		protected.GET("/saved-searches/:id/jobs", savedSearchHandler.RunSavedSearch)
+
+		// Companies
+		protected.GET("/companies/my", companyHandler.GetMyCompanies)
+		protected.POST("/companies", middleware.EmployerMiddleware(), companyHandler.CreateCompany)
+		protected.PUT("/companies/:id", companyHandler.UpdateCompany)
+		protected.GET("/companies/:id/members", companyHandler.GetMembers)
+		protected.POST("/companies/:id/members", companyHandler.AddMember)
+		protected.DELETE("/companies/:id/members/:userId", companyHandler.RemoveMember)
+
+		// Applications
+		protected.POST("/applications", applicationHandler.CreateApplication)
+		protected.GET("/applications/my", applicationHandler.GetUserApplications)
+		protected.GET("/applications/employer", middleware.EmployerMiddleware(), applicationHandler.GetEmployerApplications)
+		protected.GET("/applications/job/:jobId", applicationHandler.GetJobApplications)
+		protected.PUT("/applications/:id/status", middleware.EmployerMiddleware(), applicationHandler.UpdateApplicationStatus)
+		protected.GET("/applications/:id/history", applicationHandler.GetApplicationHistory)
+		protected.PATCH("/applications/:id", applicationHandler.UpdateApplication)
+		protected.POST("/applications/:id/withdraw", applicationHandler.WithdrawApplication)
+		protected.GET("/applications/:id/messages", applicationHandler.GetMessages)
+		protected.POST("/applications/:id/messages", applicationHandler.SendMessage)
+		protected.POST("/applications/:id/messages/read", applicationHandler.MarkMessagesRead)
+
+		// Interviews
+		protected.POST("/applications/:id/interviews", interviewHandler.ScheduleInterview)
+		protected.GET("/applications/:id/interviews", interviewHandler.GetApplicationInterviews)
+		protected.GET("/interviews/my", interviewHandler.GetMyInterviews)
+		protected.PUT("/interviews/:id", interviewHandler.RescheduleInterview)
+		protected.POST("/interviews/:id/cancel", interviewHandler.CancelInterview)
+		protected.POST("/interviews/:id/respond", interviewHandler.RespondToInterview)
+		protected.GET("/interviews/:id/invite.ics", interviewHandler.DownloadInvite)
+
+		// Admin routes
+		protected.GET("/applications/all", middleware.AdminMiddleware(), applicationHandler.GetAllApplications)
+		protected.GET("/jobs/all", middleware.AdminMiddleware(), jobHandler.GetAllJobs)
+
+		// User and role management (admins)
+		admin := protected.Group("/admin", middleware.AdminMiddleware())
+		{
+			admin.GET("/users", adminHandler.ListUsers)
+			admin.PUT("/users/:id/role", adminHandler.UpdateUserRole)
+			admin.GET("/role-changes", adminHandler.GetRoleChanges)
+
+			admin.POST("/categories", referenceHandler.CreateCategory)
+			admin.PUT("/categories/:id", referenceHandler.UpdateCategory)
+			admin.DELETE("/categories/:id", referenceHandler.DeleteCategory)
+			admin.POST("/employment-types", referenceHandler.CreateEmploymentType)
+			admin.PUT("/employment-types/:id", referenceHandler.UpdateEmploymentType)
+			admin.DELETE("/employment-types/:id", referenceHandler.DeleteEmploymentType)
+		}
+	}
+
+	return r
+}
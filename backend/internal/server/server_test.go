@@ -0,0 +1,1026 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"job-search-backend/internal/handlers"
+	"job-search-backend/internal/models"
+	"job-search-backend/internal/utils"
+
+	"github.com/gin-gonic/gin"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func init() {
+	gin.SetMode(gin.TestMode)
+}
+
+// testAPI is the full router served over HTTP, backed by the in-memory
+// repositories or, with TEST_DATABASE_URL, by PostgreSQL (see newTestBackend).
+type testAPI struct {
+	t       *testing.T
+	backend testBackend
+	url     string
+}
+
+func newTestAPI(t *testing.T) *testAPI {
+	return newTestAPIWith(t, nil)
+}
+
+// newTestAPIWith lets configure adjust the router options.
+func newTestAPIWith(t *testing.T, configure func(*Options)) *testAPI {
+	t.Helper()
+	utils.SetJWTSecret("test-secret")
+
+	backend := newTestBackend(t)
+	opts := Options{
+		Jobs:         backend.Jobs,
+		Applications: backend.Applications,
+		Users:        backend.Users,
+	}
+	if configure != nil {
+		configure(&opts)
+	}
+	srv := httptest.NewServer(NewRouter(opts))
+	t.Cleanup(srv.Close)
+	return &testAPI{t: t, backend: backend, url: srv.URL}
+}
+
+// do sends a JSON request with the given access token (none when empty) and
+// decodes the response into out.
+func (a *testAPI) do(method, path, token string, body, out interface{}) int {
+	a.t.Helper()
+
+	var payload bytes.Buffer
+	if body != nil {
+		if err := json.NewEncoder(&payload).Encode(body); err != nil {
+			a.t.Fatal(err)
+		}
+	}
+	req, err := http.NewRequest(method, a.url+path, &payload)
+	if err != nil {
+		a.t.Fatal(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		a.t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	if out != nil {
+		if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+			a.t.Fatalf("%s %s: decoding response: %v", method, path, err)
+		}
+	}
+	return resp.StatusCode
+}
+
+// register signs up a user with the given role and returns an access token.
+func (a *testAPI) register(email, role string) string {
+	a.t.Helper()
+
+	var resp struct {
+		Token string `json:"token"`
+	}
+	body := map[string]string{"email": email, "password": "secret1", "name": email, "role": role}
+	if code := a.do(http.MethodPost, "/api/auth/register", "", body, &resp); code != http.StatusCreated {
+		a.t.Fatalf("registering %s: got %d", email, code)
+	}
+	return a.login(email)
+}
+
+func (a *testAPI) login(email string) string {
+	a.t.Helper()
+
+	var resp struct {
+		Token string `json:"token"`
+	}
+	body := map[string]string{"email": email, "password": "secret1"}
+	if code := a.do(http.MethodPost, "/api/auth/login", "", body, &resp); code != http.StatusOK {
+		a.t.Fatalf("logging in as %s: got %d", email, code)
+	}
+	return resp.Token
+}
+
+// admin creates an administrator, who cannot sign up through the API, and
+// returns an access token.
+func (a *testAPI) admin(email string) string {
+	a.t.Helper()
+
+	hash, err := bcrypt.GenerateFromPassword([]byte("secret1"), bcrypt.MinCost)
+	if err != nil {
+		a.t.Fatal(err)
+	}
+	user := &models.User{Email: email, Password: string(hash), Role: models.RoleAdmin}
+	if err := a.backend.Users.Create(context.Background(), user); err != nil {
+		a.t.Fatal(err)
+	}
+	return a.login(email)
+}
+
+// userID returns the ID of the registered user.
+func (a *testAPI) userID(email string) uint {
+	a.t.Helper()
+
+	user, err := a.backend.Users.FindByEmail(context.Background(), email)
+	if err != nil {
+		a.t.Fatal(err)
+	}
+	return user.ID
+}
+
+func (a *testAPI) createJob(token string) models.Job {
+	a.t.Helper()
+
+	var resp struct {
+		Job models.Job `json:"job"`
+	}
+	if code := a.do(http.MethodPost, "/api/jobs", token, jobBody("Go developer"), &resp); code != http.StatusCreated {
+		a.t.Fatalf("creating a job: got %d", code)
+	}
+	return resp.Job
+}
+
+func jobBody(title string) map[string]interface{} {
+	return map[string]interface{}{
+		"title":       title,
+		"description": "Build the API",
+		"company":     "Acme",
+		"location":    "Remote",
+	}
+}
+
+func TestSessions(t *testing.T) {
+	api := newTestAPI(t)
+	token := api.register("dev@example.com", models.RoleJobSeeker)
+
+	if code := api.do(http.MethodGet, "/api/applications/my", "", nil, nil); code != http.StatusUnauthorized {
+		t.Fatalf("without a token: got %d, want %d", code, http.StatusUnauthorized)
+	}
+	if code := api.do(http.MethodGet, "/api/applications/my", "not-a-jwt", nil, nil); code != http.StatusUnauthorized {
+		t.Fatalf("with a malformed token: got %d, want %d", code, http.StatusUnauthorized)
+	}
+	if code := api.do(http.MethodGet, "/api/applications/my", token, nil, nil); code != http.StatusOK {
+		t.Fatalf("with a token: got %d, want %d", code, http.StatusOK)
+	}
+
+	if code := api.do(http.MethodPost, "/api/auth/login", "", map[string]string{"email": "dev@example.com", "password": "wrong1"}, nil); code != http.StatusUnauthorized {
+		t.Fatalf("login with a wrong password: got %d, want %d", code, http.StatusUnauthorized)
+	}
+
+	if code := api.do(http.MethodPost, "/api/auth/logout", token, nil, nil); code != http.StatusOK {
+		t.Fatalf("logout: got %d, want %d", code, http.StatusOK)
+	}
+	if code := api.do(http.MethodGet, "/api/applications/my", token, nil, nil); code != http.StatusUnauthorized {
+		t.Fatalf("after logout: got %d, want %d", code, http.StatusUnauthorized)
+	}
+}
+
+func TestJobOwnership(t *testing.T) {
+	api := newTestAPI(t)
+	owner := api.register("hr@example.com", models.RoleEmployer)
+	other := api.register("other@example.com", models.RoleEmployer)
+	seeker := api.register("dev@example.com", models.RoleJobSeeker)
+	admin := api.admin("admin@example.com")
+
+	if code := api.do(http.MethodPost, "/api/jobs", seeker, jobBody("Go developer"), nil); code != http.StatusForbidden {
+		t.Fatalf("job seeker creating a job: got %d, want %d", code, http.StatusForbidden)
+	}
+	job := api.createJob(owner)
+	path := fmt.Sprintf("/api/jobs/%d", job.ID)
+
+	tests := []struct {
+		name   string
+		method string
+		token  string
+		want   int
+	}{
+		{"other employer updates", http.MethodPut, other, http.StatusForbidden},
+		{"job seeker updates", http.MethodPut, seeker, http.StatusForbidden},
+		{"owner updates", http.MethodPut, owner, http.StatusOK},
+		{"admin updates", http.MethodPut, admin, http.StatusOK},
+		{"other employer deletes", http.MethodDelete, other, http.StatusForbidden},
+		{"owner deletes", http.MethodDelete, owner, http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if code := api.do(tt.method, path, tt.token, jobBody("Senior Go developer"), nil); code != tt.want {
+				t.Fatalf("got %d, want %d", code, tt.want)
+			}
+		})
+	}
+
+	if code := api.do(http.MethodGet, path, "", nil, nil); code != http.StatusNotFound {
+		t.Fatalf("deleted job: got %d, want %d", code, http.StatusNotFound)
+	}
+}
+
+func TestAdminRoutes(t *testing.T) {
+	api := newTestAPI(t)
+	employer := api.register("hr@example.com", models.RoleEmployer)
+	admin := api.admin("admin@example.com")
+
+	for _, path := range []string{"/api/jobs/all", "/api/applications/all"} {
+		if code := api.do(http.MethodGet, path, employer, nil, nil); code != http.StatusForbidden {
+			t.Errorf("GET %s as employer: got %d, want %d", path, code, http.StatusForbidden)
+		}
+		if code := api.do(http.MethodGet, path, admin, nil, nil); code != http.StatusOK {
+			t.Errorf("GET %s as admin: got %d, want %d", path, code, http.StatusOK)
+		}
+	}
+}
+
+func TestApplicationFlow(t *testing.T) {
+	api := newTestAPI(t)
+	employer := api.register("hr@example.com", models.RoleEmployer)
+	other := api.register("other@example.com", models.RoleEmployer)
+	seeker := api.register("dev@example.com", models.RoleJobSeeker)
+	job := api.createJob(employer)
+
+	apply := map[string]interface{}{"job_id": job.ID, "message": "Hello"}
+	var created struct {
+		Application models.JobApplication `json:"application"`
+	}
+	if code := api.do(http.MethodPost, "/api/applications", seeker, apply, &created); code != http.StatusCreated {
+		t.Fatalf("applying: got %d, want %d", code, http.StatusCreated)
+	}
+	if code := api.do(http.MethodPost, "/api/applications", seeker, apply, nil); code != http.StatusConflict {
+		t.Fatalf("applying twice: got %d, want %d", code, http.StatusConflict)
+	}
+
+	statusPath := fmt.Sprintf("/api/applications/%d/status", created.Application.ID)
+	screening := map[string]string{"status": models.ApplicationStatusScreening}
+	if code := api.do(http.MethodPut, statusPath, seeker, screening, nil); code != http.StatusForbidden {
+		t.Fatalf("applicant changing the status: got %d, want %d", code, http.StatusForbidden)
+	}
+	if code := api.do(http.MethodPut, statusPath, other, screening, nil); code != http.StatusForbidden {
+		t.Fatalf("other employer changing the status: got %d, want %d", code, http.StatusForbidden)
+	}
+	if code := api.do(http.MethodPut, statusPath, employer, screening, nil); code != http.StatusOK {
+		t.Fatalf("employer changing the status: got %d, want %d", code, http.StatusOK)
+	}
+
+	var mine struct {
+		Applications []models.JobApplication `json:"applications"`
+	}
+	if code := api.do(http.MethodGet, "/api/applications/my", seeker, nil, &mine); code != http.StatusOK {
+		t.Fatalf("listing applications: got %d, want %d", code, http.StatusOK)
+	}
+	if len(mine.Applications) != 1 || mine.Applications[0].Status != models.ApplicationStatusScreening {
+		t.Fatalf("unexpected applications %+v", mine.Applications)
+	}
+
+	messagesPath := fmt.Sprintf("/api/applications/%d/messages", created.Application.ID)
+	if code := api.do(http.MethodGet, messagesPath+"?page=2&limit=10", seeker, nil, nil); code != http.StatusOK {
+		t.Fatalf("listing messages: got %d, want %d", code, http.StatusOK)
+	}
+	for _, query := range []string{"page=0", "page=-3", "limit=0", "limit=-1", "limit=101"} {
+		if code := api.do(http.MethodGet, messagesPath+"?"+query, employer, nil, nil); code != http.StatusBadRequest {
+			t.Errorf("listing messages with %s: got %d, want %d", query, code, http.StatusBadRequest)
+		}
+	}
+}
+
+type fakeDB struct{ err error }
+
+func (db fakeDB) PingContext(context.Context) error { return db.err }
+
+type fakeVersions struct{ applied, latest uint }
+
+func (v fakeVersions) Version(context.Context) (uint, error) { return v.applied, nil }
+func (v fakeVersions) Latest() uint                          { return v.latest }
+
+func TestHealthProbes(t *testing.T) {
+	tests := []struct {
+		name      string
+		health    *handlers.HealthHandler
+		wantReady int
+	}{
+		{"no checks", nil, http.StatusOK},
+		{"up to date", &handlers.HealthHandler{DB: fakeDB{}, Migrations: fakeVersions{2, 2}}, http.StatusOK},
+		{"database down", &handlers.HealthHandler{DB: fakeDB{errors.New("connection refused")}}, http.StatusServiceUnavailable},
+		{"schema behind", &handlers.HealthHandler{DB: fakeDB{}, Migrations: fakeVersions{1, 2}}, http.StatusServiceUnavailable},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			api := newTestAPIWith(t, func(opts *Options) { opts.Health = tt.health })
+			if code := api.do(http.MethodGet, "/healthz", "", nil, nil); code != http.StatusOK {
+				t.Fatalf("healthz: got %d, want %d", code, http.StatusOK)
+			}
+			if code := api.do(http.MethodGet, "/readyz", "", nil, nil); code != tt.wantReady {
+				t.Fatalf("readyz: got %d, want %d", code, tt.wantReady)
+			}
+		})
+	}
+
+	health := &handlers.HealthHandler{DB: fakeDB{}}
+	api := newTestAPIWith(t, func(opts *Options) { opts.Health = health })
+	health.Drain()
+	if code := api.do(http.MethodGet, "/readyz", "", nil, nil); code != http.StatusServiceUnavailable {
+		t.Fatalf("readyz while draining: got %d, want %d", code, http.StatusServiceUnavailable)
+	}
+	if code := api.do(http.MethodGet, "/healthz", "", nil, nil); code != http.StatusOK {
+		t.Fatalf("healthz while draining: got %d, want %d", code, http.StatusOK)
+	}
+}
+
+func TestSalaryFilter(t *testing.T) {
+	api := newTestAPI(t)
+	employer := api.register("hr@example.com", models.RoleEmployer)
+
+	for _, salary := range []map[string]interface{}{
+		{"title": "Monthly RUB", "salary_min": 150000, "currency": "RUB", "salary_period": "month"},
+		{"title": "Monthly USD", "salary_min": 3000, "currency": "USD", "salary_period": "month"},
+		{"title": "Hourly USD", "salary_min": 2000, "currency": "USD", "salary_period": "hour"},
+	} {
+		body := jobBody(salary["title"].(string))
+		for k, v := range salary {
+			body[k] = v
+		}
+		if code := api.do(http.MethodPost, "/api/jobs", employer, body, nil); code != http.StatusCreated {
+			t.Fatalf("creating %s: got %d", salary["title"], code)
+		}
+	}
+
+	tests := []struct {
+		query string
+		want  []string
+	}{
+		{"salary_min=1000", []string{"Monthly RUB"}},
+		{"salary_min=1000&currency=USD", []string{"Monthly USD"}},
+		{"salary_min=1000&currency=USD&salary_period=hour", []string{"Hourly USD"}},
+		{"currency=USD", []string{"Monthly USD", "Hourly USD"}},
+	}
+	for _, tt := range tests {
+		var resp struct {
+			Jobs []models.Job `json:"jobs"`
+		}
+		if code := api.do(http.MethodGet, "/api/jobs?sort=title&"+tt.query, "", nil, &resp); code != http.StatusOK {
+			t.Fatalf("%s: got %d", tt.query, code)
+		}
+		got := map[string]bool{}
+		for _, job := range resp.Jobs {
+			got[job.Title] = true
+		}
+		if len(got) != len(tt.want) {
+			t.Errorf("%s: got %v, want %v", tt.query, got, tt.want)
+			continue
+		}
+		for _, title := range tt.want {
+			if !got[title] {
+				t.Errorf("%s: got %v, want %v", tt.query, got, tt.want)
+			}
+		}
+	}
+}
+
+func TestSavedJobsPaging(t *testing.T) {
+	api := newTestAPI(t)
+	employer := api.register("hr@example.com", models.RoleEmployer)
+	seeker := api.register("dev@example.com", models.RoleJobSeeker)
+	for i := 0; i < 3; i++ {
+		job := api.createJob(employer)
+		if code := api.do(http.MethodPost, fmt.Sprintf("/api/jobs/%d/save", job.ID), seeker, nil, nil); code != http.StatusOK {
+			t.Fatalf("saving a job: got %d", code)
+		}
+	}
+
+	var resp struct {
+		SavedJobs []json.RawMessage `json:"saved_jobs"`
+		Total     int64             `json:"total"`
+		Page      int               `json:"page"`
+	}
+	if code := api.do(http.MethodGet, "/api/jobs/saved?page=2&limit=2", seeker, nil, &resp); code != http.StatusOK {
+		t.Fatalf("got %d", code)
+	}
+	if len(resp.SavedJobs) != 1 || resp.Total != 3 || resp.Page != 2 {
+		t.Fatalf("got %d of %d on page %d, want 1 of 3 on page 2", len(resp.SavedJobs), resp.Total, resp.Page)
+	}
+
+	for _, query := range []string{"page=0", "page=-1", "page=x", "limit=0", "limit=-5", "limit=1000"} {
+		if code := api.do(http.MethodGet, "/api/jobs/saved?"+query, seeker, nil, nil); code != http.StatusBadRequest {
+			t.Errorf("%s: got %d, want %d", query, code, http.StatusBadRequest)
+		}
+	}
+}
+
+func TestNotificationsPaging(t *testing.T) {
+	api := newTestAPI(t)
+	token := api.register("dev@example.com", models.RoleJobSeeker)
+
+	for _, query := range []string{"page=0", "page=x", "limit=0", "limit=-1", "limit=101"} {
+		if code := api.do(http.MethodGet, "/api/notifications?"+query, token, nil, nil); code != http.StatusBadRequest {
+			t.Errorf("%s: got %d, want %d", query, code, http.StatusBadRequest)
+		}
+	}
+}
+
+func TestStreamTokenNotLogged(t *testing.T) {
+	var logs bytes.Buffer
+	defer func(w io.Writer) { gin.DefaultWriter = w }(gin.DefaultWriter)
+	gin.DefaultWriter = &logs
+	api := newTestAPI(t)
+
+	var resp struct {
+		Error string `json:"error"`
+	}
+	if code := api.do(http.MethodGet, "/api/notifications/stream?access_token=leaked.token.value", "", nil, &resp); code != http.StatusUnauthorized {
+		t.Fatalf("got %d, want %d", code, http.StatusUnauthorized)
+	}
+	if resp.Error != "Invalid token" {
+		t.Fatalf("got %q: the query token was not used", resp.Error)
+	}
+	if !strings.Contains(logs.String(), "/api/notifications/stream") {
+		t.Fatalf("request not logged: %q", logs.String())
+	}
+	if strings.Contains(logs.String(), "leaked.token.value") {
+		t.Fatalf("token logged: %q", logs.String())
+	}
+}
+
+func TestUpdateJobRefusedReopen(t *testing.T) {
+	api := newTestAPI(t)
+	employer := api.register("hr@example.com", models.RoleEmployer)
+	seeker := api.register("dev@example.com", models.RoleJobSeeker)
+
+	body := jobBody("Go developer")
+	body["max_applications"] = 1
+	var created struct {
+		Job models.Job `json:"job"`
+	}
+	if code := api.do(http.MethodPost, "/api/jobs", employer, body, &created); code != http.StatusCreated {
+		t.Fatalf("creating a job: got %d", code)
+	}
+	jobPath := fmt.Sprintf("/api/jobs/%d", created.Job.ID)
+	if code := api.do(http.MethodPost, "/api/applications", seeker, map[string]interface{}{"job_id": created.Job.ID}, nil); code != http.StatusCreated {
+		t.Fatalf("applying: got %d", code)
+	}
+
+	// The job is full, so reopening it together with an edit fails as a whole
+	body["title"] = "Senior Go developer"
+	body["is_active"] = true
+	if code := api.do(http.MethodPut, jobPath, employer, body, nil); code != http.StatusConflict {
+		t.Fatalf("reopening a full job: got %d, want %d", code, http.StatusConflict)
+	}
+	var got struct {
+		Job models.Job `json:"job"`
+	}
+	if code := api.do(http.MethodGet, jobPath, employer, nil, &got); code != http.StatusOK {
+		t.Fatalf("fetching the job: got %d", code)
+	}
+	if got.Job.Title != "Go developer" || got.Job.IsActive {
+		t.Fatalf("got %q, active %v; want the job unchanged", got.Job.Title, got.Job.IsActive)
+	}
+
+	body["max_applications"] = 2
+	if code := api.do(http.MethodPut, jobPath, employer, body, &got); code != http.StatusOK {
+		t.Fatalf("reopening with a higher cap: got %d, want %d", code, http.StatusOK)
+	}
+	if got.Job.Title != "Senior Go developer" || !got.Job.IsActive {
+		t.Fatalf("got %q, active %v; want the edited job reopened", got.Job.Title, got.Job.IsActive)
+	}
+}
+
+func TestUpdateJobSchedule(t *testing.T) {
+	api := newTestAPI(t)
+	employer := api.register("hr@example.com", models.RoleEmployer)
+	job := api.createJob(employer)
+	jobPath := fmt.Sprintf("/api/jobs/%d", job.ID)
+
+	update := func(body map[string]interface{}) models.Job {
+		t.Helper()
+		var got struct {
+			Job models.Job `json:"job"`
+		}
+		if code := api.do(http.MethodPut, jobPath, employer, body, &got); code != http.StatusOK {
+			t.Fatalf("updating the job: got %d, want %d", code, http.StatusOK)
+		}
+		return got.Job
+	}
+
+	// A future publish_at takes the job offline until the scheduler publishes it
+	body := jobBody("Go developer")
+	body["publish_at"] = time.Now().Add(24 * time.Hour)
+	if got := update(body); got.IsActive || got.ClosedAt != nil {
+		t.Fatalf("got active %v, closed_at %v; want the job scheduled", got.IsActive, got.ClosedAt)
+	}
+
+	// Clearing it publishes the job right away
+	delete(body, "publish_at")
+	if got := update(body); !got.IsActive {
+		t.Fatal("got the job inactive, want it published")
+	}
+
+	// A closed job stays closed unless is_active reopens it
+	if code := api.do(http.MethodPost, jobPath+"/close", employer, nil, nil); code != http.StatusOK {
+		t.Fatalf("closing the job: got %d", code)
+	}
+	if got := update(body); got.IsActive || got.ClosedAt == nil {
+		t.Fatalf("got active %v, closed_at %v; want the job still closed", got.IsActive, got.ClosedAt)
+	}
+	body["is_active"] = true
+	if got := update(body); !got.IsActive || got.ClosedAt != nil {
+		t.Fatalf("got active %v, closed_at %v; want the job reopened", got.IsActive, got.ClosedAt)
+	}
+}
+
+func TestRemoveCompanyMember(t *testing.T) {
+	api := newTestAPI(t)
+	owner := api.register("owner@example.com", models.RoleEmployer)
+	recruiter := api.register("hr@example.com", models.RoleEmployer)
+	company := &models.Company{Name: "Acme", Slug: "acme"}
+	api.backend.AddCompany(company,
+		models.CompanyMember{UserID: api.userID("owner@example.com"), Role: models.CompanyRoleOwner},
+		models.CompanyMember{UserID: api.userID("hr@example.com"), Role: models.CompanyRoleRecruiter},
+	)
+	membersPath := fmt.Sprintf("/api/companies/%d/members/", company.ID)
+
+	if code := api.do(http.MethodDelete, membersPath+fmt.Sprint(api.userID("owner@example.com")), recruiter, nil, nil); code != http.StatusForbidden {
+		t.Fatalf("recruiter removing the owner: got %d, want %d", code, http.StatusForbidden)
+	}
+	if code := api.do(http.MethodDelete, membersPath+fmt.Sprint(api.userID("owner@example.com")), owner, nil, nil); code != http.StatusConflict {
+		t.Fatalf("removing the last owner: got %d, want %d", code, http.StatusConflict)
+	}
+	if code := api.do(http.MethodDelete, membersPath+fmt.Sprint(api.userID("hr@example.com")), owner, nil, nil); code != http.StatusOK {
+		t.Fatalf("removing the recruiter: got %d, want %d", code, http.StatusOK)
+	}
+	if code := api.do(http.MethodDelete, membersPath+fmt.Sprint(api.userID("hr@example.com")), owner, nil, nil); code != http.StatusNotFound {
+		t.Fatalf("removing the recruiter twice: got %d, want %d", code, http.StatusNotFound)
+	}
+}
+
+func TestRemovedMemberLosesJobAccess(t *testing.T) {
+	api := newTestAPI(t)
+	owner := api.register("owner@example.com", models.RoleEmployer)
+	recruiter := api.register("hr@example.com", models.RoleEmployer)
+	seeker := api.register("dev@example.com", models.RoleJobSeeker)
+	company := &models.Company{Name: "Acme", Slug: "acme"}
+	api.backend.AddCompany(company,
+		models.CompanyMember{UserID: api.userID("owner@example.com"), Role: models.CompanyRoleOwner},
+		models.CompanyMember{UserID: api.userID("hr@example.com"), Role: models.CompanyRoleRecruiter},
+	)
+
+	body := jobBody("Go developer")
+	body["company_id"] = company.ID
+	var job struct {
+		Job models.Job `json:"job"`
+	}
+	if code := api.do(http.MethodPost, "/api/jobs", recruiter, body, &job); code != http.StatusCreated {
+		t.Fatalf("creating a company job: got %d", code)
+	}
+	var applied struct {
+		Application models.JobApplication `json:"application"`
+	}
+	if code := api.do(http.MethodPost, "/api/applications", seeker, map[string]interface{}{"job_id": job.Job.ID}, &applied); code != http.StatusCreated {
+		t.Fatalf("applying: got %d", code)
+	}
+
+	removePath := fmt.Sprintf("/api/companies/%d/members/%d", company.ID, api.userID("hr@example.com"))
+	if code := api.do(http.MethodDelete, removePath, owner, nil, nil); code != http.StatusOK {
+		t.Fatalf("removing the recruiter: got %d", code)
+	}
+
+	jobPath := fmt.Sprintf("/api/jobs/%d", job.Job.ID)
+	applicationsPath := fmt.Sprintf("/api/applications/job/%d", job.Job.ID)
+	messagesPath := fmt.Sprintf("/api/applications/%d/messages", applied.Application.ID)
+	for _, tt := range []struct {
+		name   string
+		method string
+		path   string
+		token  string
+		want   int
+	}{
+		{"removed recruiter updates the job", http.MethodPut, jobPath, recruiter, http.StatusForbidden},
+		{"removed recruiter lists applications", http.MethodGet, applicationsPath, recruiter, http.StatusForbidden},
+		{"removed recruiter reads messages", http.MethodGet, messagesPath, recruiter, http.StatusForbidden},
+		{"owner updates the job", http.MethodPut, jobPath, owner, http.StatusOK},
+		{"owner lists applications", http.MethodGet, applicationsPath, owner, http.StatusOK},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			if code := api.do(tt.method, tt.path, tt.token, body, nil); code != tt.want {
+				t.Fatalf("got %d, want %d", code, tt.want)
+			}
+		})
+	}
+
+	var listed struct {
+		Applications []models.JobApplication `json:"applications"`
+	}
+	if code := api.do(http.MethodGet, "/api/applications/employer", recruiter, nil, &listed); code != http.StatusOK {
+		t.Fatalf("listing employer applications: got %d", code)
+	}
+	if len(listed.Applications) != 0 {
+		t.Fatalf("removed recruiter still sees %d applications", len(listed.Applications))
+	}
+}
+
+func TestSalarySortMixedCurrencies(t *testing.T) {
+	api := newTestAPI(t)
+	employer := api.register("hr@example.com", models.RoleEmployer)
+
+	for _, salary := range []map[string]interface{}{
+		{"title": "RUB 300k", "salary_min": 300000, "currency": "RUB", "salary_period": "month"},
+		{"title": "USD 7k", "salary_min": 7000, "currency": "USD", "salary_period": "month"},
+		{"title": "RUB 100k", "salary_min": 100000, "currency": "RUB", "salary_period": "month"},
+		{"title": "RUB 2k hourly", "salary_min": 2000, "currency": "RUB", "salary_period": "hour"},
+		{"title": "USD 9k", "salary_min": 9000, "currency": "USD", "salary_period": "month"},
+	} {
+		body := jobBody(salary["title"].(string))
+		for k, v := range salary {
+			body[k] = v
+		}
+		if code := api.do(http.MethodPost, "/api/jobs", employer, body, nil); code != http.StatusCreated {
+			t.Fatalf("creating %s: got %d", salary["title"], code)
+		}
+	}
+
+	tests := []struct {
+		query string
+		want  []string
+	}{
+		{"sort=salary_desc", []string{"RUB 300k", "RUB 100k"}},
+		{"sort=salary_asc", []string{"RUB 100k", "RUB 300k"}},
+		{"sort=salary_desc&currency=USD", []string{"USD 9k", "USD 7k"}},
+		{"sort=salary_desc&salary_period=hour", []string{"RUB 2k hourly"}},
+	}
+	for _, tt := range tests {
+		var resp struct {
+			Jobs []models.Job `json:"jobs"`
+		}
+		if code := api.do(http.MethodGet, "/api/jobs?"+tt.query, "", nil, &resp); code != http.StatusOK {
+			t.Fatalf("%s: got %d", tt.query, code)
+		}
+		var got []string
+		for _, job := range resp.Jobs {
+			got = append(got, job.Title)
+		}
+		if strings.Join(got, ", ") != strings.Join(tt.want, ", ") {
+			t.Errorf("%s: got %v, want %v", tt.query, got, tt.want)
+		}
+	}
+}
+
+// createJobs posts one job per body and returns them in order.
+func (a *testAPI) createJobs(token string, bodies ...map[string]interface{}) []models.Job {
+	a.t.Helper()
+
+	var jobs []models.Job
+	for _, body := range bodies {
+		var resp struct {
+			Job models.Job `json:"job"`
+		}
+		if code := a.do(http.MethodPost, "/api/jobs", token, body, &resp); code != http.StatusCreated {
+			a.t.Fatalf("creating %s: got %d", body["title"], code)
+		}
+		jobs = append(jobs, resp.Job)
+	}
+	return jobs
+}
+
+// jobBodyWith is jobBody with extra fields.
+func jobBodyWith(title string, fields map[string]interface{}) map[string]interface{} {
+	body := jobBody(title)
+	for k, v := range fields {
+		body[k] = v
+	}
+	return body
+}
+
+func TestJobCursorPaging(t *testing.T) {
+	api := newTestAPI(t)
+	employer := api.register("hr@example.com", models.RoleEmployer)
+	api.createJobs(employer,
+		jobBodyWith("C", map[string]interface{}{"salary_min": 300000}),
+		jobBodyWith("A", map[string]interface{}{"salary_min": 100000}),
+		jobBodyWith("E", nil),
+		jobBodyWith("B", map[string]interface{}{"salary_min": 200000, "salary_max": 250000}),
+		jobBodyWith("D", map[string]interface{}{"salary_min": 400000}),
+	)
+
+	tests := []struct {
+		sort string
+		want string
+	}{
+		{"newest", "D B E A C"},
+		{"title", "A B C D E"},
+		{"salary_desc", "D C B A E"},
+		{"salary_asc", "A B C D E"},
+	}
+	for _, tt := range tests {
+		var titles []string
+		path := "/api/jobs?limit=2&sort=" + tt.sort
+		for pages := 0; path != ""; pages++ {
+			if pages > 5 {
+				t.Fatalf("%s: the cursor does not advance", tt.sort)
+			}
+			var resp struct {
+				Jobs       []models.Job `json:"jobs"`
+				Total      int64        `json:"total"`
+				NextCursor *string      `json:"next_cursor"`
+			}
+			if code := api.do(http.MethodGet, path, "", nil, &resp); code != http.StatusOK {
+				t.Fatalf("%s: got %d", path, code)
+			}
+			if resp.Total != 5 {
+				t.Fatalf("%s: total %d, want 5", path, resp.Total)
+			}
+			for _, job := range resp.Jobs {
+				titles = append(titles, job.Title)
+			}
+			path = ""
+			if resp.NextCursor != nil {
+				path = "/api/jobs?limit=2&sort=" + tt.sort + "&cursor=" + *resp.NextCursor
+			}
+		}
+		if got := strings.Join(titles, " "); got != tt.want {
+			t.Errorf("%s: got %s, want %s", tt.sort, got, tt.want)
+		}
+	}
+
+	if code := api.do(http.MethodGet, "/api/jobs?sort=title&cursor=garbage", "", nil, nil); code != http.StatusBadRequest {
+		t.Errorf("invalid cursor: got %d, want %d", code, http.StatusBadRequest)
+	}
+}
+
+func TestJobSearch(t *testing.T) {
+	api := newTestAPI(t)
+	employer := api.register("hr@example.com", models.RoleEmployer)
+	api.createJobs(employer,
+		jobBodyWith("Kubernetes engineer", map[string]interface{}{"description": "Operate production clusters"}),
+		jobBodyWith("Go developer", map[string]interface{}{"description": "Build the API"}),
+		jobBodyWith("Platform engineer", map[string]interface{}{"requirements": "Kubernetes and Terraform"}),
+	)
+
+	tests := []struct {
+		query string
+		want  []string
+	}{
+		{"search=kubernetes&sort=title", []string{"Kubernetes engineer", "Platform engineer"}},
+		{"search=clusters", []string{"Kubernetes engineer"}},
+		{"search=Terraform", []string{"Platform engineer"}},
+		{"search=python", nil},
+	}
+	for _, tt := range tests {
+		var resp struct {
+			Jobs []models.Job `json:"jobs"`
+			Sort string       `json:"sort"`
+		}
+		if code := api.do(http.MethodGet, "/api/jobs?"+tt.query, "", nil, &resp); code != http.StatusOK {
+			t.Fatalf("%s: got %d", tt.query, code)
+		}
+		var got []string
+		for _, job := range resp.Jobs {
+			got = append(got, job.Title)
+		}
+		if strings.Join(got, ", ") != strings.Join(tt.want, ", ") {
+			t.Errorf("%s: got %v, want %v", tt.query, got, tt.want)
+		}
+		if !strings.Contains(tt.query, "sort=") && resp.Sort != "relevance" {
+			t.Errorf("%s: sorted by %q, want relevance", tt.query, resp.Sort)
+		}
+	}
+}
+
+func TestJobFacets(t *testing.T) {
+	api := newTestAPI(t)
+	employer := api.register("hr@example.com", models.RoleEmployer)
+	api.createJobs(employer,
+		jobBodyWith("Go developer", map[string]interface{}{"location": "Moscow", "salary_min": 100000}),
+		jobBodyWith("Go lead", map[string]interface{}{"location": "Moscow", "salary_min": 200000}),
+		jobBodyWith("QA engineer", map[string]interface{}{"location": "Berlin", "salary_min": 5000, "currency": "EUR"}),
+		jobBodyWith("Designer", map[string]interface{}{"location": "Berlin"}),
+	)
+
+	type value struct {
+		Value string `json:"value"`
+		Count int64  `json:"count"`
+	}
+	var resp struct {
+		Total     int64   `json:"total"`
+		Locations []value `json:"locations"`
+		Salary    struct {
+			Currency string `json:"currency"`
+			Buckets  []struct {
+				Min   int   `json:"min"`
+				Count int64 `json:"count"`
+			} `json:"buckets"`
+			NotSpecified int64 `json:"not_specified"`
+		} `json:"salary"`
+	}
+	if code := api.do(http.MethodGet, "/api/jobs/facets", "", nil, &resp); code != http.StatusOK {
+		t.Fatalf("got %d", code)
+	}
+	if resp.Total != 4 {
+		t.Errorf("total: got %d, want 4", resp.Total)
+	}
+	locations := map[string]int64{}
+	for _, v := range resp.Locations {
+		locations[v.Value] = v.Count
+	}
+	if locations["Moscow"] != 2 || locations["Berlin"] != 2 {
+		t.Errorf("locations: got %v", resp.Locations)
+	}
+	buckets := map[int]int64{}
+	for _, b := range resp.Salary.Buckets {
+		buckets[b.Min] = b.Count
+	}
+	if resp.Salary.Currency != "RUB" || buckets[100000] != 2 || buckets[200000] != 1 || buckets[300000] != 0 {
+		t.Errorf("salary: got %+v", resp.Salary)
+	}
+	if resp.Salary.NotSpecified != 1 {
+		t.Errorf("salary not specified: got %d, want 1", resp.Salary.NotSpecified)
+	}
+
+	if code := api.do(http.MethodGet, "/api/jobs/facets?search=go", "", nil, &resp); code != http.StatusOK || resp.Total != 2 {
+		t.Errorf("facets of a search: got %d with total %d, want 2", code, resp.Total)
+	}
+}
+
+func TestConcurrentApplications(t *testing.T) {
+	api := newTestAPI(t)
+	employer := api.register("hr@example.com", models.RoleEmployer)
+	job := api.createJobs(employer, jobBodyWith("Go developer", map[string]interface{}{"max_applications": 2}))[0]
+
+	var seekers []string
+	for i := 0; i < 6; i++ {
+		seekers = append(seekers, api.register(fmt.Sprintf("dev%d@example.com", i), models.RoleJobSeeker))
+	}
+
+	codes := make([]int, len(seekers))
+	var wg sync.WaitGroup
+	for i, token := range seekers {
+		wg.Add(1)
+		go func(i int, token string) {
+			defer wg.Done()
+			codes[i] = api.do(http.MethodPost, "/api/applications", token, map[string]interface{}{"job_id": job.ID}, nil)
+		}(i, token)
+	}
+	wg.Wait()
+
+	created := 0
+	for _, code := range codes {
+		switch code {
+		case http.StatusCreated:
+			created++
+		case http.StatusConflict:
+		default:
+			t.Fatalf("unexpected status %d among %v", code, codes)
+		}
+	}
+	if created != 2 {
+		t.Fatalf("%d applications accepted, want 2: %v", created, codes)
+	}
+
+	var got struct {
+		Job models.Job `json:"job"`
+	}
+	if code := api.do(http.MethodGet, fmt.Sprintf("/api/jobs/%d", job.ID), employer, nil, &got); code != http.StatusOK {
+		t.Fatalf("fetching the job: got %d", code)
+	}
+	if got.Job.IsActive || got.Job.CloseReason != models.JobCloseReasonFilled {
+		t.Fatalf("job active %v, close reason %q; want it closed as filled", got.Job.IsActive, got.Job.CloseReason)
+	}
+}
+
+func TestConcurrentFirstProfileSave(t *testing.T) {
+	if os.Getenv("TEST_DATABASE_URL") == "" {
+		t.Skip("the profile handler needs TEST_DATABASE_URL")
+	}
+	api := newTestAPI(t)
+	seeker := api.register("dev@example.com", models.RoleJobSeeker)
+
+	bodies := []map[string]interface{}{
+		{"phone": "+7 900 000-00-00"},
+		{"skills": "Go, PostgreSQL"},
+	}
+	codes := make([]int, len(bodies))
+	var wg sync.WaitGroup
+	for i, body := range bodies {
+		wg.Add(1)
+		go func(i int, body map[string]interface{}) {
+			defer wg.Done()
+			codes[i] = api.do(http.MethodPatch, "/api/profile", seeker, body, nil)
+		}(i, body)
+	}
+	wg.Wait()
+	for _, code := range codes {
+		if code != http.StatusOK {
+			t.Fatalf("saving the profile: got %v, want both %d", codes, http.StatusOK)
+		}
+	}
+
+	var got struct {
+		User models.User `json:"user"`
+	}
+	if code := api.do(http.MethodGet, "/api/profile", seeker, nil, &got); code != http.StatusOK {
+		t.Fatalf("fetching the profile: got %d", code)
+	}
+	if profile := got.User.UserProfile; profile == nil || profile.Phone == "" || profile.Skills == "" {
+		t.Fatalf("got profile %+v, want both patches applied", profile)
+	}
+}
+
+func TestConcurrentOwnerRemoval(t *testing.T) {
+	api := newTestAPI(t)
+	first := api.register("first@example.com", models.RoleEmployer)
+	second := api.register("second@example.com", models.RoleEmployer)
+	firstID, secondID := api.userID("first@example.com"), api.userID("second@example.com")
+	company := &models.Company{Name: "Acme", Slug: "acme"}
+	api.backend.AddCompany(company,
+		models.CompanyMember{UserID: firstID, Role: models.CompanyRoleOwner},
+		models.CompanyMember{UserID: secondID, Role: models.CompanyRoleOwner},
+	)
+
+	// Each owner removes the other one at the same time
+	var codes [2]int
+	var wg sync.WaitGroup
+	for i, remove := range []struct {
+		token  string
+		target uint
+	}{{first, secondID}, {second, firstID}} {
+		wg.Add(1)
+		go func(i int, token string, target uint) {
+			defer wg.Done()
+			codes[i] = api.do(http.MethodDelete, fmt.Sprintf("/api/companies/%d/members/%d", company.ID, target), token, nil, nil)
+		}(i, remove.token, remove.target)
+	}
+	wg.Wait()
+
+	removed := 0
+	for _, code := range codes {
+		if code == http.StatusOK {
+			removed++
+		}
+	}
+	if removed != 1 {
+		t.Fatalf("got %v, want exactly one removal", codes)
+	}
+	owners := 0
+	for _, id := range []uint{firstID, secondID} {
+		owner, err := api.backend.Jobs.IsCompanyMember(context.Background(), company.ID, id, models.CompanyRoleOwner)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if owner {
+			owners++
+		}
+	}
+	if owners != 1 {
+		t.Fatalf("%d owners left, want 1", owners)
+	}
+}
+
+func TestInterviewVideoURL(t *testing.T) {
+	api := newTestAPI(t)
+	employer := api.register("hr@example.com", models.RoleEmployer)
+	seeker := api.register("dev@example.com", models.RoleJobSeeker)
+	job := api.createJob(employer)
+	var applied struct {
+		Application models.JobApplication `json:"application"`
+	}
+	if code := api.do(http.MethodPost, "/api/applications", seeker, map[string]interface{}{"job_id": job.ID}, &applied); code != http.StatusCreated {
+		t.Fatalf("applying: got %d", code)
+	}
+
+	path := fmt.Sprintf("/api/applications/%d/interviews", applied.Application.ID)
+	for _, videoURL := range []string{"javascript:alert(1)", "data:text/html;base64,PHNjcmlwdD4=", "file:///etc/passwd"} {
+		body := map[string]interface{}{
+			"starts_at":        time.Now().Add(24 * time.Hour),
+			"duration_minutes": 60,
+			"video_url":        videoURL,
+		}
+		if code := api.do(http.MethodPost, path, employer, body, nil); code != http.StatusBadRequest {
+			t.Errorf("video_url %q: got %d, want %d", videoURL, code, http.StatusBadRequest)
+		}
+	}
+}
+
+func TestAdminListPaging(t *testing.T) {
+	api := newTestAPI(t)
+	admin := api.admin("admin@example.com")
+
+	for _, query := range []string{"page=0", "page=x", "limit=0", "limit=-1", "limit=101"} {
+		if code := api.do(http.MethodGet, "/api/admin/users?"+query, admin, nil, nil); code != http.StatusBadRequest {
+			t.Errorf("users with %s: got %d, want %d", query, code, http.StatusBadRequest)
+		}
+		if code := api.do(http.MethodGet, "/api/admin/role-changes?"+query, admin, nil, nil); code != http.StatusBadRequest {
+			t.Errorf("role changes with %s: got %d, want %d", query, code, http.StatusBadRequest)
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLocalRoundTrip(t *testing.T) {
+	s, err := NewLocal(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	key := "resumes/4/1690000000.pdf"
+
+	if err := s.Put(ctx, key, strings.NewReader("resume"), 6, "application/pdf"); err != nil {
+		t.Fatal(err)
+	}
+	r, err := s.Get(ctx, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := io.ReadAll(r)
+	r.Close()
+	if err != nil || string(got) != "resume" {
+		t.Fatalf("got %q, %v", got, err)
+	}
+
+	if err := s.Delete(ctx, key); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Get(ctx, key); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("get after delete: got %v, want ErrNotFound", err)
+	}
+	if err := s.Delete(ctx, key); err != nil {
+		t.Fatalf("deleting a missing file: %v", err)
+	}
+}
+
+func TestLocalRejectsPathTraversal(t *testing.T) {
+	root := t.TempDir()
+	dir := filepath.Join(root, "uploads")
+	s, err := NewLocal(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	secret := filepath.Join(root, "secret.txt")
+	if err := os.WriteFile(secret, []byte("secret"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	for _, key := range []string{"", "/", "../secret.txt", "resumes/../../secret.txt", "..", "resumes/..", `..\secret.txt`} {
+		if err := s.Put(ctx, key, strings.NewReader("overwritten"), 11, ""); err == nil {
+			t.Errorf("put %q: expected an error", key)
+		}
+		if r, err := s.Get(ctx, key); err == nil {
+			r.Close()
+			t.Errorf("get %q: expected an error", key)
+		}
+		if err := s.Delete(ctx, key); err == nil {
+			t.Errorf("delete %q: expected an error", key)
+		}
+	}
+
+	if data, err := os.ReadFile(secret); err != nil || string(data) != "secret" {
+		t.Fatalf("file outside the storage directory changed: %q, %v", data, err)
+	}
+
+	// Absolute keys stay inside the directory
+	if err := s.Put(ctx, "/resumes/1.pdf", strings.NewReader("x"), 1, ""); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "resumes", "1.pdf")); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,229 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+const (
+	testAccessKey = "AKIDEXAMPLE"
+	testSecretKey = "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY"
+	testRegion    = "eu-central-1"
+	testBucket    = "resumes"
+)
+
+var testNow = time.Date(2023, 7, 15, 12, 30, 0, 0, time.UTC)
+
+// fakeS3 is a stand-in for an S3-compatible service. It keeps objects in
+// memory and rejects requests whose SigV4 signature it cannot reproduce.
+type fakeS3 struct {
+	t *testing.T
+
+	mu      sync.Mutex
+	objects map[string][]byte
+	types   map[string]string
+	fail    int // when set, every request is answered with this status
+}
+
+func newFakeS3(t *testing.T) (*fakeS3, *S3) {
+	t.Helper()
+	fake := &fakeS3{t: t, objects: map[string][]byte{}, types: map[string]string{}}
+	srv := httptest.NewServer(fake)
+	t.Cleanup(srv.Close)
+
+	s, err := NewS3(S3Config{
+		Endpoint:        srv.URL + "/",
+		Region:          testRegion,
+		Bucket:          testBucket,
+		AccessKeyID:     testAccessKey,
+		SecretAccessKey: testSecretKey,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.now = func() time.Time { return testNow }
+	return fake, s
+}
+
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if err := f.verify(r); err != nil {
+		http.Error(w, "<Error><Code>SignatureDoesNotMatch</Code></Error>", http.StatusForbidden)
+		return
+	}
+	if f.fail != 0 {
+		http.Error(w, "<Error><Code>InternalError</Code></Error>", f.fail)
+		return
+	}
+
+	prefix := "/" + testBucket + "/"
+	if !strings.HasPrefix(r.URL.Path, prefix) {
+		http.Error(w, "<Error><Code>NoSuchBucket</Code></Error>", http.StatusNotFound)
+		return
+	}
+	key := strings.TrimPrefix(r.URL.Path, prefix)
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	switch r.Method {
+	case http.MethodPut:
+		if r.ContentLength < 0 {
+			http.Error(w, "<Error><Code>MissingContentLength</Code></Error>", http.StatusLengthRequired)
+			return
+		}
+		data, err := io.ReadAll(r.Body)
+		if err != nil {
+			f.t.Error(err)
+		}
+		f.objects[key] = data
+		f.types[key] = r.Header.Get("Content-Type")
+	case http.MethodGet:
+		data, ok := f.objects[key]
+		if !ok {
+			http.Error(w, "<Error><Code>NoSuchKey</Code></Error>", http.StatusNotFound)
+			return
+		}
+		w.Write(data)
+	case http.MethodDelete:
+		if _, ok := f.objects[key]; !ok {
+			http.Error(w, "<Error><Code>NoSuchKey</Code></Error>", http.StatusNotFound)
+			return
+		}
+		delete(f.objects, key)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+// verify recomputes the SigV4 signature of r from the secret key and
+// compares it with the one in the Authorization header.
+func (f *fakeS3) verify(r *http.Request) error {
+	day := testNow.Format("20060102")
+	scope := day + "/" + testRegion + "/s3/aws4_request"
+	if got := r.Header.Get("X-Amz-Date"); got != testNow.Format("20060102T150405Z") {
+		return fmt.Errorf("X-Amz-Date %q", got)
+	}
+
+	canonicalRequest := r.Method + "\n" +
+		r.URL.EscapedPath() + "\n" +
+		r.URL.RawQuery + "\n" +
+		"host:" + r.Host + "\n" +
+		"x-amz-content-sha256:" + r.Header.Get("X-Amz-Content-Sha256") + "\n" +
+		"x-amz-date:" + r.Header.Get("X-Amz-Date") + "\n" +
+		"\n" +
+		"host;x-amz-content-sha256;x-amz-date\n" +
+		r.Header.Get("X-Amz-Content-Sha256")
+	stringToSign := "AWS4-HMAC-SHA256\n" + r.Header.Get("X-Amz-Date") + "\n" + scope + "\n" + sha256Hex(canonicalRequest)
+
+	key := []byte("AWS4" + testSecretKey)
+	for _, part := range []string{day, testRegion, "s3", "aws4_request"} {
+		key = hmacSHA256(key, part)
+	}
+	want := fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=host;x-amz-content-sha256;x-amz-date, Signature=%s",
+		testAccessKey, scope, hex.EncodeToString(hmacSHA256(key, stringToSign)))
+	if got := r.Header.Get("Authorization"); got != want {
+		return fmt.Errorf("Authorization %q, want %q", got, want)
+	}
+	return nil
+}
+
+func TestS3RoundTrip(t *testing.T) {
+	fake, s := newFakeS3(t)
+	ctx := context.Background()
+	key := "resumes/4/CV (final)+ü.pdf"
+	content := []byte("%PDF-1.4 resume")
+
+	if err := s.Put(ctx, key, bytes.NewReader(content), int64(len(content)), "application/pdf"); err != nil {
+		t.Fatal(err)
+	}
+	if got := fake.types[key]; got != "application/pdf" {
+		t.Errorf("content type: got %q", got)
+	}
+
+	r, err := s.Get(ctx, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := io.ReadAll(r)
+	r.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, content) {
+		t.Fatalf("got %q, want %q", got, content)
+	}
+
+	if err := s.Delete(ctx, key); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Get(ctx, key); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("get after delete: got %v, want ErrNotFound", err)
+	}
+	if err := s.Delete(ctx, key); err != nil {
+		t.Fatalf("deleting a missing object: %v", err)
+	}
+}
+
+func TestS3Errors(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("wrong secret", func(t *testing.T) {
+		_, s := newFakeS3(t)
+		s.cfg.SecretAccessKey = "wrong"
+		err := s.Put(ctx, "a.pdf", strings.NewReader("x"), 1, "")
+		if err == nil || !strings.Contains(err.Error(), "403") {
+			t.Fatalf("got %v, want a 403 error", err)
+		}
+	})
+
+	t.Run("server error", func(t *testing.T) {
+		fake, s := newFakeS3(t)
+		fake.fail = http.StatusInternalServerError
+		for name, err := range map[string]error{
+			"put":    s.Put(ctx, "a.pdf", strings.NewReader("x"), 1, ""),
+			"delete": s.Delete(ctx, "a.pdf"),
+		} {
+			if err == nil || errors.Is(err, ErrNotFound) || !strings.Contains(err.Error(), "InternalError") {
+				t.Errorf("%s: got %v, want the server error", name, err)
+			}
+		}
+		if _, err := s.Get(ctx, "a.pdf"); err == nil || errors.Is(err, ErrNotFound) {
+			t.Errorf("get: got %v, want the server error", err)
+		}
+	})
+
+	t.Run("unreachable", func(t *testing.T) {
+		_, s := newFakeS3(t)
+		s.endpoint.Host = "127.0.0.1:1"
+		if _, err := s.Get(ctx, "a.pdf"); err == nil {
+			t.Fatal("expected a connection error")
+		}
+	})
+}
+
+func TestNewS3(t *testing.T) {
+	valid := S3Config{Endpoint: "http://minio:9000", Bucket: "b", AccessKeyID: "id", SecretAccessKey: "secret"}
+	if s, err := NewS3(valid); err != nil || s.cfg.Region != "us-east-1" {
+		t.Fatalf("got %v, %v; want the default region", s, err)
+	}
+
+	for name, cfg := range map[string]S3Config{
+		"no bucket":      {Endpoint: valid.Endpoint, AccessKeyID: "id", SecretAccessKey: "secret"},
+		"no credentials": {Endpoint: valid.Endpoint, Bucket: "b"},
+		"bad endpoint":   {Endpoint: "minio", Bucket: "b", AccessKeyID: "id", SecretAccessKey: "secret"},
+	} {
+		if _, err := NewS3(cfg); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package utils
+
+import (
+	"errors"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// GenerateActionToken signs a single-purpose token such as an email
+// verification or password reset link. Each purpose uses its own signing key,
+// so these tokens can never be used as access tokens or for another purpose.
+// tokenID identifies the token server-side to make it single-use.
+func GenerateActionToken(userID uint, purpose string, tokenID string, ttl time.Duration) (string, error) {
+	now := time.Now()
+	claims := jwt.MapClaims{
+		"user_id": userID,
+		"purpose": purpose,
+		"jti":     tokenID,
+		"iat":     now.Unix(),
+		"exp":     now.Add(ttl).Unix(),
+	}
+
+	key, err := actionKey(purpose)
+	if err != nil {
+		return "", err
+	}
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	return token.SignedString(key)
+}
+
+// ValidateActionToken checks the signature, expiry and purpose of an action
+// token and returns the user and token IDs it carries.
+func ValidateActionToken(tokenString string, purpose string) (uint, string, error) {
+	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
+		return actionKey(purpose)
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}))
+	if err != nil {
+		return 0, "", err
+	}
+
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok || !token.Valid || claims["purpose"] != purpose {
+		return 0, "", errors.New("invalid token")
+	}
+	userID, _ := claims["user_id"].(float64)
+	tokenID, _ := claims["jti"].(string)
+	if userID == 0 || tokenID == "" {
+		return 0, "", errors.New("invalid token")
+	}
+	return uint(userID), tokenID, nil
+}
+
+func actionKey(purpose string) ([]byte, error) {
+	if len(jwtSecret) == 0 {
+		return nil, ErrNoJWTSecret
+	}
+	return append(append([]byte{}, jwtSecret...), "/"+purpose...), nil
+}
@@ -1,39 +1,118 @@
 package utils
 
 import (
-	"os"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
 )
 
-func GenerateJWT(userID uint, role string) (string, error) {
+var (
+	// AccessTokenTTL is the lifetime of JWT access tokens. Clients renew them
+	// with a refresh token, which lives for RefreshTokenTTL.
+	AccessTokenTTL  = 15 * time.Minute
+	RefreshTokenTTL = 30 * 24 * time.Hour
+)
+
+// ErrNoJWTSecret is returned when tokens are signed or checked before
+// SetJWTSecret: an empty key would make every token forgeable.
+var ErrNoJWTSecret = errors.New("JWT secret is not configured")
+
+var jwtSecret []byte
+
+// SetJWTSecret sets the key that signs access tokens and, with a suffix per
+// purpose, action tokens. It is called once at startup.
+func SetJWTSecret(secret string) {
+	jwtSecret = []byte(secret)
+}
+
+// Claims are the values carried by an access token.
+type Claims struct {
+	UserID    uint
+	Role      string
+	SessionID string
+	ExpiresAt time.Time
+}
+
+// GenerateJWT issues an access token bound to a login session. The session is
+// checked on every request, so revoking it invalidates the token immediately.
+func GenerateJWT(userID uint, role string, sessionID string) (string, error) {
+	if len(jwtSecret) == 0 {
+		return "", ErrNoJWTSecret
+	}
+
+	now := time.Now()
 	claims := jwt.MapClaims{
 		"user_id": userID,
 		"role":    role,
-		"exp":     time.Now().Add(time.Hour * 24 * 7).Unix(), // 7 days
+		"sid":     sessionID,
+		"iat":     now.Unix(),
+		"exp":     now.Add(AccessTokenTTL).Unix(),
 	}
 
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString([]byte(os.Getenv("JWT_SECRET")))
+	return token.SignedString(jwtSecret)
 }
 
-func ValidateJWT(tokenString string) (uint, string, error) {
+func ValidateJWT(tokenString string) (Claims, error) {
 	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
-		return []byte(os.Getenv("JWT_SECRET")), nil
-	})
+		if len(jwtSecret) == 0 {
+			return nil, ErrNoJWTSecret
+		}
+		return jwtSecret, nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}))
 
 	if err != nil {
-		return 0, "", err
+		return Claims{}, err
 	}
 
 	if claims, ok := token.Claims.(jwt.MapClaims); ok && token.Valid {
-		userID := uint(claims["user_id"].(float64))
-		role := claims["role"].(string)
-		return userID, role, nil
+		userID, _ := claims["user_id"].(float64)
+		role, _ := claims["role"].(string)
+		sessionID, _ := claims["sid"].(string)
+		exp, err := claims.GetExpirationTime()
+		if err != nil || exp == nil || userID == 0 || sessionID == "" {
+			return Claims{}, errors.New("token is missing required claims")
+		}
+		return Claims{UserID: uint(userID), Role: role, SessionID: sessionID, ExpiresAt: exp.Time}, nil
 	}
 
-	return 0, "", jwt.ErrSignatureInvalid
+	return Claims{}, jwt.ErrSignatureInvalid
 }
 
+// GenerateRefreshToken returns a random opaque refresh token together with the
+// hash that is stored server-side.
+func GenerateRefreshToken() (token string, hash string, err error) {
+	token, err = randomToken(32)
+	if err != nil {
+		return "", "", err
+	}
+	return token, HashToken(token), nil
+}
+
+// NewRandomID returns a random hex identifier, e.g. for login sessions.
+func NewRandomID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// HashToken hashes an opaque token for storage and lookup.
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
 
+func randomToken(size int) (string, error) {
+	b := make([]byte, size)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
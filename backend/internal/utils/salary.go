@@ -0,0 +1,152 @@
+package utils
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const (
+	SalaryPeriodHour  = "hour"
+	SalaryPeriodMonth = "month"
+	SalaryPeriodYear  = "year"
+
+	DefaultCurrency = "RUB"
+)
+
+// SalaryRange is the structured form of a free-text salary such as
+// "200000-300000 руб." or "от 3 000 $ в час".
+type SalaryRange struct {
+	Min      *int
+	Max      *int
+	Currency string
+	Period   string
+}
+
+var (
+	salaryNumber = regexp.MustCompile(`(\d[\d\s\x{00A0}.,]*)\s*([kк]|тыс\.?)?`)
+	salaryUpTo   = regexp.MustCompile(`(^|[\s(])(до|up to)\s`)
+	salaryFrom   = regexp.MustCompile(`(^|[\s(])(от|from)\s`)
+
+	currencyAliases = []struct {
+		code    string
+		aliases []string
+	}{
+		{"USD", []string{"$", "usd", "долл"}},
+		{"EUR", []string{"€", "eur", "евро"}},
+		{"RUB", []string{"₽", "руб", "rub", "р."}},
+	}
+
+	periodAliases = []struct {
+		period  string
+		aliases []string
+	}{
+		{SalaryPeriodHour, []string{"/ч", "в час", "час", "hour", "/h", "hourly"}},
+		{SalaryPeriodYear, []string{"в год", "год", "year", "annual", "/y"}},
+	}
+)
+
+// ParseSalary extracts a salary range from free text. It understands ranges
+// ("100000-150000"), open bounds ("от 100 000", "до 150k", "from $3000"),
+// thousands suffixes ("150k", "150 тыс.") and common currency and period
+// markers. ok is false when no amount could be found.
+func ParseSalary(text string) (SalaryRange, bool) {
+	result := SalaryRange{Currency: DefaultCurrency, Period: SalaryPeriodMonth}
+	lower := strings.ToLower(strings.TrimSpace(text))
+	if lower == "" {
+		return result, false
+	}
+
+	for _, c := range currencyAliases {
+		if containsAny(lower, c.aliases) {
+			result.Currency = c.code
+			break
+		}
+	}
+	for _, p := range periodAliases {
+		if containsAny(lower, p.aliases) {
+			result.Period = p.period
+			break
+		}
+	}
+
+	var amounts []int
+	for _, m := range salaryNumber.FindAllStringSubmatch(lower, 2) {
+		digits := strings.NewReplacer(" ", "", "\u00a0", "", ",", "", ".", "").Replace(strings.TrimRight(m[1], " .,"))
+		value, err := strconv.Atoi(digits)
+		if err != nil {
+			continue
+		}
+		if m[2] != "" {
+			value *= 1000
+		}
+		amounts = append(amounts, value)
+	}
+
+	switch len(amounts) {
+	case 0:
+		return result, false
+	case 1:
+		value := amounts[0]
+		switch {
+		case salaryUpTo.MatchString(lower):
+			result.Max = &value
+		case salaryFrom.MatchString(lower):
+			result.Min = &value
+		default:
+			result.Min = &value
+			result.Max = &value
+		}
+	default:
+		min, max := amounts[0], amounts[1]
+		if min > max {
+			min, max = max, min
+		}
+		result.Min = &min
+		result.Max = &max
+	}
+	return result, true
+}
+
+// FormatSalary renders a salary range in the same style the free-text field
+// used to hold, e.g. "200000-300000 руб." or "от 3000 USD в час".
+func FormatSalary(min, max *int, currency, period string) string {
+	if min == nil && max == nil {
+		return ""
+	}
+
+	unit := currency
+	if currency == "" || currency == "RUB" {
+		unit = "руб."
+	}
+
+	var amount string
+	switch {
+	case min != nil && max != nil && *min == *max:
+		amount = strconv.Itoa(*min)
+	case min != nil && max != nil:
+		amount = fmt.Sprintf("%d-%d", *min, *max)
+	case min != nil:
+		amount = fmt.Sprintf("от %d", *min)
+	default:
+		amount = fmt.Sprintf("до %d", *max)
+	}
+
+	switch period {
+	case SalaryPeriodHour:
+		return amount + " " + unit + " в час"
+	case SalaryPeriodYear:
+		return amount + " " + unit + " в год"
+	}
+	return amount + " " + unit
+}
+
+func containsAny(s string, substrings []string) bool {
+	for _, sub := range substrings {
+		if strings.Contains(s, sub) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,20 @@
+DROP TABLE IF EXISTS
+    employment_types,
+    categories,
+    notifications,
+    saved_searches,
+    saved_jobs,
+    action_tokens,
+    role_changes,
+    refresh_tokens,
+    interview_interviewers,
+    interviews,
+    message_attachments,
+    application_messages,
+    application_status_events,
+    job_applications,
+    jobs,
+    company_members,
+    companies,
+    user_profiles,
+    users;
@@ -0,0 +1,346 @@
+-- Schema as it stood when versioned migrations replaced AutoMigrate.
+-- Databases created before that are adopted at this version (see
+-- database.Migrate) instead of running this file.
+
+CREATE TABLE users (
+    id bigserial PRIMARY KEY,
+    email text NOT NULL UNIQUE,
+    password text NOT NULL,
+    name text NOT NULL,
+    role text DEFAULT 'job_seeker',
+    email_verified_at timestamptz,
+    created_at timestamptz,
+    updated_at timestamptz,
+    deleted_at timestamptz
+);
+CREATE INDEX idx_users_deleted_at ON users (deleted_at);
+
+CREATE TABLE user_profiles (
+    id bigserial PRIMARY KEY,
+    user_id bigint NOT NULL,
+    phone text,
+    location text,
+    work_mode text,
+    experience text,
+    skills text,
+    education text,
+    resume text,
+    city text,
+    country varchar(2),
+    latitude decimal,
+    longitude decimal,
+    resume_key text,
+    resume_file_name text,
+    resume_content_type text,
+    resume_size bigint,
+    resume_uploaded_at timestamptz,
+    created_at timestamptz,
+    updated_at timestamptz,
+    deleted_at timestamptz,
+    CONSTRAINT fk_users_user_profile FOREIGN KEY (user_id) REFERENCES users (id)
+);
+CREATE INDEX idx_user_profiles_deleted_at ON user_profiles (deleted_at);
+CREATE INDEX idx_user_profiles_country ON user_profiles (country);
+CREATE UNIQUE INDEX idx_user_profiles_user_id ON user_profiles (user_id);
+
+CREATE TABLE companies (
+    id bigserial PRIMARY KEY,
+    name text NOT NULL,
+    slug text NOT NULL,
+    description text,
+    website text,
+    logo_url text,
+    location text,
+    size text,
+    created_at timestamptz,
+    updated_at timestamptz,
+    deleted_at timestamptz
+);
+CREATE INDEX idx_companies_deleted_at ON companies (deleted_at);
+CREATE UNIQUE INDEX idx_companies_slug ON companies (slug);
+
+CREATE TABLE company_members (
+    id bigserial PRIMARY KEY,
+    company_id bigint NOT NULL,
+    user_id bigint NOT NULL,
+    role text NOT NULL DEFAULT 'recruiter',
+    created_at timestamptz,
+    CONSTRAINT fk_company_members_user FOREIGN KEY (user_id) REFERENCES users (id),
+    CONSTRAINT fk_companies_members FOREIGN KEY (company_id) REFERENCES companies (id)
+);
+CREATE INDEX idx_company_members_user_id ON company_members (user_id);
+CREATE UNIQUE INDEX idx_company_members_company_user ON company_members (company_id, user_id);
+
+CREATE TABLE jobs (
+    id bigserial PRIMARY KEY,
+    title text NOT NULL,
+    description text,
+    company text NOT NULL,
+    company_id bigint,
+    location text,
+    work_mode text DEFAULT 'onsite',
+    salary_min bigint,
+    salary_max bigint,
+    currency varchar(3) DEFAULT 'RUB',
+    salary_period text DEFAULT 'month',
+    type text,
+    category text,
+    requirements text,
+    benefits text,
+    employer_id bigint NOT NULL,
+    is_active boolean DEFAULT true,
+    publish_at timestamptz,
+    expires_at timestamptz,
+    max_applications bigint,
+    closed_at timestamptz,
+    close_reason text,
+    created_at timestamptz,
+    updated_at timestamptz,
+    deleted_at timestamptz,
+    city text,
+    country varchar(2),
+    latitude decimal,
+    longitude decimal,
+    CONSTRAINT fk_jobs_company_profile FOREIGN KEY (company_id) REFERENCES companies (id),
+    CONSTRAINT fk_jobs_employer FOREIGN KEY (employer_id) REFERENCES users (id)
+);
+CREATE INDEX idx_jobs_company_id ON jobs (company_id);
+CREATE INDEX idx_jobs_country ON jobs (country);
+CREATE INDEX idx_jobs_deleted_at ON jobs (deleted_at);
+CREATE INDEX idx_jobs_expires_at ON jobs (expires_at);
+CREATE INDEX idx_jobs_publish_at ON jobs (publish_at);
+CREATE INDEX idx_jobs_salary_max ON jobs (salary_max);
+CREATE INDEX idx_jobs_salary_min ON jobs (salary_min);
+CREATE INDEX idx_jobs_work_mode ON jobs (work_mode);
+CREATE INDEX idx_jobs_coordinates ON jobs (latitude, longitude);
+
+-- Full-text search: titles weigh the most, then company, requirements and
+-- description. Text is stemmed both as Russian and as English because
+-- postings freely mix the two languages.
+ALTER TABLE jobs ADD COLUMN search_vector tsvector GENERATED ALWAYS AS (
+    setweight(to_tsvector('russian', coalesce(title, '')), 'A') ||
+    setweight(to_tsvector('english', coalesce(title, '')), 'A') ||
+    setweight(to_tsvector('simple', coalesce(company, '')), 'B') ||
+    setweight(to_tsvector('russian', coalesce(requirements, '')), 'C') ||
+    setweight(to_tsvector('english', coalesce(requirements, '')), 'C') ||
+    setweight(to_tsvector('russian', coalesce(description, '')), 'D') ||
+    setweight(to_tsvector('english', coalesce(description, '')), 'D')
+) STORED;
+CREATE INDEX idx_jobs_search_vector ON jobs USING GIN (search_vector);
+
+CREATE TABLE job_applications (
+    id bigserial PRIMARY KEY,
+    job_id bigint NOT NULL,
+    user_id bigint NOT NULL,
+    status text DEFAULT 'applied',
+    message text,
+    created_at timestamptz,
+    updated_at timestamptz,
+    deleted_at timestamptz,
+    CONSTRAINT fk_job_applications_job FOREIGN KEY (job_id) REFERENCES jobs (id),
+    CONSTRAINT fk_job_applications_user FOREIGN KEY (user_id) REFERENCES users (id)
+);
+CREATE INDEX idx_job_applications_deleted_at ON job_applications (deleted_at);
+CREATE INDEX idx_job_applications_status ON job_applications (status);
+
+CREATE TABLE application_status_events (
+    id bigserial PRIMARY KEY,
+    application_id bigint NOT NULL,
+    from_status text,
+    to_status text NOT NULL,
+    changed_by_id bigint NOT NULL,
+    note text,
+    created_at timestamptz,
+    CONSTRAINT fk_application_status_events_changed_by FOREIGN KEY (changed_by_id) REFERENCES users (id)
+);
+CREATE INDEX idx_application_status_events_application_id ON application_status_events (application_id);
+
+CREATE TABLE application_messages (
+    id bigserial PRIMARY KEY,
+    application_id bigint NOT NULL,
+    sender_id bigint NOT NULL,
+    body text NOT NULL,
+    read_at timestamptz,
+    created_at timestamptz,
+    CONSTRAINT fk_application_messages_sender FOREIGN KEY (sender_id) REFERENCES users (id)
+);
+CREATE INDEX idx_application_messages_application_id ON application_messages (application_id);
+
+CREATE TABLE message_attachments (
+    id bigserial PRIMARY KEY,
+    message_id bigint NOT NULL,
+    name text NOT NULL,
+    url text NOT NULL,
+    CONSTRAINT fk_application_messages_attachments FOREIGN KEY (message_id) REFERENCES application_messages (id)
+);
+CREATE INDEX idx_message_attachments_message_id ON message_attachments (message_id);
+
+CREATE TABLE interviews (
+    id bigserial PRIMARY KEY,
+    application_id bigint NOT NULL,
+    scheduled_by_id bigint NOT NULL,
+    starts_at timestamptz NOT NULL,
+    duration_minutes bigint NOT NULL,
+    location text,
+    video_url text,
+    status text DEFAULT 'proposed',
+    proposed_starts_at timestamptz,
+    candidate_note text,
+    sequence bigint,
+    created_at timestamptz,
+    updated_at timestamptz,
+    CONSTRAINT fk_interviews_application FOREIGN KEY (application_id) REFERENCES job_applications (id)
+);
+CREATE INDEX idx_interviews_status ON interviews (status);
+CREATE INDEX idx_interviews_starts_at ON interviews (starts_at);
+CREATE INDEX idx_interviews_application_id ON interviews (application_id);
+
+CREATE TABLE interview_interviewers (
+    interview_id bigint,
+    user_id bigint,
+    PRIMARY KEY (interview_id, user_id),
+    CONSTRAINT fk_interview_interviewers_user FOREIGN KEY (user_id) REFERENCES users (id),
+    CONSTRAINT fk_interview_interviewers_interview FOREIGN KEY (interview_id) REFERENCES interviews (id)
+);
+
+CREATE TABLE refresh_tokens (
+    id bigserial PRIMARY KEY,
+    user_id bigint NOT NULL,
+    session_id text NOT NULL,
+    token_hash text NOT NULL,
+    expires_at timestamptz NOT NULL,
+    revoked_at timestamptz,
+    user_agent text,
+    ip text,
+    created_at timestamptz
+);
+CREATE UNIQUE INDEX idx_refresh_tokens_token_hash ON refresh_tokens (token_hash);
+CREATE INDEX idx_refresh_tokens_session_id ON refresh_tokens (session_id);
+CREATE INDEX idx_refresh_tokens_user_id ON refresh_tokens (user_id);
+
+CREATE TABLE role_changes (
+    id bigserial PRIMARY KEY,
+    user_id bigint NOT NULL,
+    changed_by_id bigint NOT NULL,
+    old_role text,
+    new_role text,
+    reason text,
+    created_at timestamptz,
+    CONSTRAINT fk_role_changes_user FOREIGN KEY (user_id) REFERENCES users (id),
+    CONSTRAINT fk_role_changes_changed_by FOREIGN KEY (changed_by_id) REFERENCES users (id)
+);
+CREATE INDEX idx_role_changes_user_id ON role_changes (user_id);
+
+CREATE TABLE action_tokens (
+    id bigserial PRIMARY KEY,
+    user_id bigint NOT NULL,
+    purpose text NOT NULL,
+    token_id text NOT NULL,
+    expires_at timestamptz NOT NULL,
+    used_at timestamptz,
+    created_at timestamptz
+);
+CREATE UNIQUE INDEX idx_action_tokens_token_id ON action_tokens (token_id);
+CREATE INDEX idx_action_tokens_user_id ON action_tokens (user_id);
+
+CREATE TABLE saved_jobs (
+    id bigserial PRIMARY KEY,
+    user_id bigint NOT NULL,
+    job_id bigint NOT NULL,
+    created_at timestamptz,
+    CONSTRAINT fk_saved_jobs_job FOREIGN KEY (job_id) REFERENCES jobs (id)
+);
+CREATE INDEX idx_saved_jobs_job_id ON saved_jobs (job_id);
+CREATE UNIQUE INDEX idx_saved_jobs_user_job ON saved_jobs (user_id, job_id);
+
+CREATE TABLE saved_searches (
+    id bigserial PRIMARY KEY,
+    user_id bigint NOT NULL,
+    name text NOT NULL,
+    filter_search text,
+    filter_lang text,
+    filter_category text,
+    filter_location text,
+    filter_type text,
+    filter_salary_min bigint,
+    filter_salary_max bigint,
+    filter_currency varchar(3),
+    filter_work_mode text,
+    filter_country varchar(2),
+    filter_near text,
+    filter_latitude decimal,
+    filter_longitude decimal,
+    filter_radius_km decimal,
+    frequency text DEFAULT 'daily',
+    email_alerts boolean,
+    alerts_checked_at timestamptz,
+    created_at timestamptz,
+    updated_at timestamptz
+);
+CREATE INDEX idx_saved_searches_user_id ON saved_searches (user_id);
+CREATE INDEX idx_saved_searches_alerts_checked_at ON saved_searches (alerts_checked_at);
+
+CREATE TABLE notifications (
+    id bigserial PRIMARY KEY,
+    user_id bigint NOT NULL,
+    type text NOT NULL,
+    title text NOT NULL,
+    body text,
+    link text,
+    read_at timestamptz,
+    created_at timestamptz
+);
+CREATE INDEX idx_notifications_user_id ON notifications (user_id);
+
+CREATE TABLE categories (
+    id bigserial PRIMARY KEY,
+    slug text NOT NULL,
+    parent_id bigint,
+    name_ru text NOT NULL,
+    name_en text NOT NULL,
+    position bigint DEFAULT 0,
+    created_at timestamptz,
+    updated_at timestamptz,
+    CONSTRAINT fk_categories_children FOREIGN KEY (parent_id) REFERENCES categories (id)
+);
+CREATE INDEX idx_categories_parent_id ON categories (parent_id);
+CREATE UNIQUE INDEX idx_categories_slug ON categories (slug);
+
+CREATE TABLE employment_types (
+    id bigserial PRIMARY KEY,
+    slug text NOT NULL,
+    name_ru text NOT NULL,
+    name_en text NOT NULL,
+    position bigint DEFAULT 0,
+    created_at timestamptz,
+    updated_at timestamptz
+);
+CREATE UNIQUE INDEX idx_employment_types_slug ON employment_types (slug);
+
+-- Default reference data; admins manage the lists afterwards
+INSERT INTO categories (slug, name_ru, name_en, position, created_at, updated_at) VALUES
+    ('it', 'IT', 'IT', 1, NOW(), NOW()),
+    ('design', 'Дизайн', 'Design', 2, NOW(), NOW()),
+    ('marketing', 'Маркетинг', 'Marketing', 3, NOW(), NOW()),
+    ('sales', 'Продажи', 'Sales', 4, NOW(), NOW()),
+    ('finance', 'Финансы', 'Finance', 5, NOW(), NOW()),
+    ('hr', 'Персонал', 'HR', 6, NOW(), NOW()),
+    ('management', 'Управление', 'Management', 7, NOW(), NOW()),
+    ('other', 'Другое', 'Other', 100, NOW(), NOW());
+
+INSERT INTO categories (slug, parent_id, name_ru, name_en, position, created_at, updated_at)
+SELECT v.slug, parent.id, v.name_ru, v.name_en, v.position, NOW(), NOW()
+FROM (VALUES
+    ('development', 'Разработка', 'Software development', 1),
+    ('qa', 'Тестирование', 'QA', 2),
+    ('devops', 'DevOps', 'DevOps', 3),
+    ('data', 'Данные и аналитика', 'Data and analytics', 4)
+) AS v (slug, name_ru, name_en, position)
+JOIN categories parent ON parent.slug = 'it';
+
+INSERT INTO employment_types (slug, name_ru, name_en, position, created_at, updated_at) VALUES
+    ('full-time', 'Полная занятость', 'Full-time', 1, NOW(), NOW()),
+    ('part-time', 'Частичная занятость', 'Part-time', 2, NOW(), NOW()),
+    ('contract', 'Контракт', 'Contract', 3, NOW(), NOW()),
+    ('internship', 'Стажировка', 'Internship', 4, NOW(), NOW()),
+    ('temporary', 'Временная работа', 'Temporary', 5, NOW(), NOW());
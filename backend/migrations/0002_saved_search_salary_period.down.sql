@@ -0,0 +1 @@
+ALTER TABLE saved_searches DROP COLUMN filter_salary_period;
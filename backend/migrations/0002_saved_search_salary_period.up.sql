@@ -0,0 +1,9 @@
+-- Salary bounds of saved searches are compared within one currency and
+-- period; searches saved without them default to monthly roubles, as new
+-- ones do.
+ALTER TABLE saved_searches ADD COLUMN filter_salary_period text;
+
+UPDATE saved_searches
+SET filter_currency = COALESCE(NULLIF(filter_currency, ''), 'RUB'),
+    filter_salary_period = 'month'
+WHERE filter_salary_min IS NOT NULL OR filter_salary_max IS NOT NULL;
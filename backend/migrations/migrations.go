@@ -0,0 +1,12 @@
+// Package migrations embeds the versioned SQL migrations of the database
+// schema, applied by internal/migrate.
+//
+// Each version is a pair of files, NNNN_name.up.sql and NNNN_name.down.sql;
+// create new ones with `main migrate create <name>`. seed.sql is demo data,
+// not a migration.
+package migrations
+
+import "embed"
+
+//go:embed *.up.sql *.down.sql
+var FS embed.FS
@@ -1,6 +1,7 @@
 -- Тестовые данные для приложения поиска работы
 
 -- Очистка существующих данных (если есть)
+DELETE FROM application_status_events;
 DELETE FROM job_applications;
 DELETE FROM jobs;
 DELETE FROM user_profiles;
@@ -11,6 +12,7 @@ ALTER SEQUENCE users_id_seq RESTART WITH 1;
 ALTER SEQUENCE user_profiles_id_seq RESTART WITH 1;
 ALTER SEQUENCE jobs_id_seq RESTART WITH 1;
 ALTER SEQUENCE job_applications_id_seq RESTART WITH 1;
+ALTER SEQUENCE application_status_events_id_seq RESTART WITH 1;
 
 -- Вставка тестовых пользователей
 INSERT INTO users (email, password, name, role, created_at, updated_at) VALUES
@@ -31,10 +33,10 @@ INSERT INTO user_profiles (user_id, phone, location, experience, skills, educati
 (6, '+7 (999) 678-90-12', 'Екатеринбург', '1 год в маркетинге', 'SMM, Google Analytics, контент-маркетинг', 'УрФУ, Маркетинг', 'Маркетолог, специализирующийся на digital-маркетинге', NOW(), NOW());
 
 -- Вставка тестовых вакансий
-INSERT INTO jobs (title, description, company, location, salary, type, category, requirements, benefits, employer_id, is_active, created_at, updated_at) VALUES
+INSERT INTO jobs (title, description, company, location, salary_min, salary_max, currency, salary_period, type, category, requirements, benefits, employer_id, is_active, created_at, updated_at) VALUES
 ('Senior Go Developer', 
 'Ищем опытного Go разработчика для работы над высоконагруженными системами. Проект связан с финтехом, работа в команде из 5-7 человек.',
-'FinTech Solutions', 'Москва', '200000-300000 руб.', 'full-time', 'IT', 
+'FinTech Solutions', 'Москва', 200000, 300000, 'RUB', 'month', 'full-time', 'it', 
 '• Опыт работы с Go от 3 лет
 • Знание PostgreSQL, Redis
 • Опыт работы с Docker, Kubernetes
@@ -49,7 +51,7 @@ INSERT INTO jobs (title, description, company, location, salary, type, category,
 
 ('Frontend Developer (React)', 
 'Развиваем платформу для онлайн-обучения. Нужен React разработчик для создания пользовательских интерфейсов.',
-'EduTech Startup', 'Санкт-Петербург', '150000-250000 руб.', 'full-time', 'IT',
+'EduTech Startup', 'Санкт-Петербург', 150000, 250000, 'RUB', 'month', 'full-time', 'it',
 '• Опыт работы с React от 2 лет
 • Знание TypeScript, Redux
 • Опыт работы с Material-UI или аналогичными библиотеками
@@ -63,7 +65,7 @@ INSERT INTO jobs (title, description, company, location, salary, type, category,
 
 ('UI/UX Designer', 
 'Создаем новый продукт в сфере e-commerce. Ищем талантливого дизайнера для создания пользовательских интерфейсов.',
-'ShopTech', 'Москва', '120000-180000 руб.', 'full-time', 'IT',
+'ShopTech', 'Москва', 120000, 180000, 'RUB', 'month', 'full-time', 'it',
 '• Опыт работы в UI/UX дизайне от 2 лет
 • Владение Figma, Sketch, Adobe Creative Suite
 • Понимание принципов пользовательского опыта
@@ -77,7 +79,7 @@ INSERT INTO jobs (title, description, company, location, salary, type, category,
 
 ('Digital Marketing Manager', 
 'Развиваем digital-направление компании. Ищем маркетолога для работы с социальными сетями и контент-маркетингом.',
-'Marketing Agency', 'Москва', '80000-120000 руб.', 'full-time', 'Marketing',
+'Marketing Agency', 'Москва', 80000, 120000, 'RUB', 'month', 'full-time', 'marketing',
 '• Опыт работы в digital-маркетинге от 1 года
 • Знание SMM, Google Analytics, Яндекс.Метрики
 • Опыт создания контент-планов
@@ -91,7 +93,7 @@ INSERT INTO jobs (title, description, company, location, salary, type, category,
 
 ('DevOps Engineer', 
 'Автоматизируем процессы разработки и развертывания. Ищем DevOps инженера для работы с облачной инфраструктурой.',
-'CloudTech', 'Москва', '180000-280000 руб.', 'full-time', 'IT',
+'CloudTech', 'Москва', 180000, 280000, 'RUB', 'month', 'full-time', 'it',
 '• Опыт работы с AWS/Azure/GCP
 • Знание Docker, Kubernetes
 • Опыт работы с CI/CD (GitLab CI, Jenkins)
@@ -105,7 +107,7 @@ INSERT INTO jobs (title, description, company, location, salary, type, category,
 
 ('Junior Python Developer', 
 'Развиваем платформу для анализа данных. Ищем начинающего Python разработчика для работы с машинным обучением.',
-'DataScience Corp', 'Санкт-Петербург', '100000-150000 руб.', 'full-time', 'IT',
+'DataScience Corp', 'Санкт-Петербург', 100000, 150000, 'RUB', 'month', 'full-time', 'it',
 '• Знание Python, pandas, numpy
 • Базовые знания машинного обучения
 • Опыт работы с SQL
@@ -119,7 +121,7 @@ INSERT INTO jobs (title, description, company, location, salary, type, category,
 
 ('Product Manager', 
 'Управляем развитием мобильного приложения. Ищем продукт-менеджера для работы с командой разработки.',
-'MobileApp Inc', 'Москва', '150000-220000 руб.', 'full-time', 'IT',
+'MobileApp Inc', 'Москва', 150000, 220000, 'RUB', 'month', 'full-time', 'it',
 '• Опыт работы в продуктовой разработке от 2 лет
 • Понимание Agile/Scrum методологий
 • Навыки аналитики и работы с метриками
@@ -133,7 +135,7 @@ INSERT INTO jobs (title, description, company, location, salary, type, category,
 
 ('Content Manager', 
 'Создаем контент для IT-блога и социальных сетей. Ищем контент-менеджера с техническим бэкграундом.',
-'TechBlog', 'Москва', '70000-100000 руб.', 'part-time', 'Marketing',
+'TechBlog', 'Москва', 70000, 100000, 'RUB', 'month', 'part-time', 'marketing',
 '• Опыт создания технического контента
 • Знание IT-трендов и технологий
 • Навыки копирайтинга
@@ -147,16 +149,25 @@ INSERT INTO jobs (title, description, company, location, salary, type, category,
 
 -- Вставка тестовых заявок
 INSERT INTO job_applications (job_id, user_id, status, message, created_at, updated_at) VALUES
-(1, 4, 'pending', 'Здравствуйте! Меня очень заинтересовала вакансия Senior Go Developer. У меня есть опыт работы с Go и PostgreSQL, а также опыт работы с Docker. Готов к собеседованию!', NOW(), NOW()),
-(1, 6, 'pending', 'Добрый день! Хотя у меня нет прямого опыта с Go, я быстро обучаюсь и имею опыт с Python. Готов изучить Go для этой позиции.', NOW(), NOW()),
-(2, 4, 'accepted', 'Отличная вакансия! У меня есть опыт с React и TypeScript. Работал над похожими проектами в сфере образования.', NOW(), NOW()),
-(2, 5, 'pending', 'Привет! Я UI/UX дизайнер, но также изучаю React. Могу привнести дизайнерский взгляд в разработку интерфейсов.', NOW(), NOW()),
-(3, 5, 'accepted', 'Идеальная позиция для меня! У меня есть опыт создания интерфейсов для e-commerce проектов. Портфолио прилагаю.', NOW(), NOW()),
-(4, 6, 'pending', 'Здравствуйте! У меня есть опыт в digital-маркетинге и SMM. Работал с различными инструментами аналитики.', NOW(), NOW()),
+(1, 4, 'applied', 'Здравствуйте! Меня очень заинтересовала вакансия Senior Go Developer. У меня есть опыт работы с Go и PostgreSQL, а также опыт работы с Docker. Готов к собеседованию!', NOW(), NOW()),
+(1, 6, 'applied', 'Добрый день! Хотя у меня нет прямого опыта с Go, я быстро обучаюсь и имею опыт с Python. Готов изучить Go для этой позиции.', NOW(), NOW()),
+(2, 4, 'offer', 'Отличная вакансия! У меня есть опыт с React и TypeScript. Работал над похожими проектами в сфере образования.', NOW(), NOW()),
+(2, 5, 'applied', 'Привет! Я UI/UX дизайнер, но также изучаю React. Могу привнести дизайнерский взгляд в разработку интерфейсов.', NOW(), NOW()),
+(3, 5, 'offer', 'Идеальная позиция для меня! У меня есть опыт создания интерфейсов для e-commerce проектов. Портфолио прилагаю.', NOW(), NOW()),
+(4, 6, 'applied', 'Здравствуйте! У меня есть опыт в digital-маркетинге и SMM. Работал с различными инструментами аналитики.', NOW(), NOW()),
 (5, 4, 'rejected', 'Интересная позиция, но у меня пока нет опыта с Kubernetes. Возможно, рассмотрите меня на более junior позицию?', NOW(), NOW()),
-(6, 4, 'pending', 'Отличная возможность для роста! У меня есть базовые знания Python и желание изучать машинное обучение.', NOW(), NOW()),
-(7, 6, 'pending', 'Хотя у меня нет прямого опыта в продуктовой разработке, я изучал Agile методологии и имею аналитический склад ума.', NOW(), NOW()),
-(8, 6, 'accepted', 'Идеально подходит! У меня есть опыт создания технического контента и работы с IT-аудиторией.', NOW(), NOW());
+(6, 4, 'applied', 'Отличная возможность для роста! У меня есть базовые знания Python и желание изучать машинное обучение.', NOW(), NOW()),
+(7, 6, 'applied', 'Хотя у меня нет прямого опыта в продуктовой разработке, я изучал Agile методологии и имею аналитический склад ума.', NOW(), NOW()),
+(8, 6, 'offer', 'Идеально подходит! У меня есть опыт создания технического контента и работы с IT-аудиторией.', NOW(), NOW());
 
 
 
+
+-- История статусов заявок
+INSERT INTO application_status_events (application_id, from_status, to_status, changed_by_id, note, created_at)
+SELECT a.id, '', 'applied', a.user_id, '', NOW() FROM job_applications a;
+
+INSERT INTO application_status_events (application_id, from_status, to_status, changed_by_id, note, created_at)
+SELECT a.id, 'applied', a.status, j.employer_id, '', NOW()
+FROM job_applications a JOIN jobs j ON j.id = a.job_id
+WHERE a.status <> 'applied';
@@ -11,6 +11,10 @@ import CreateJob from './pages/CreateJob.tsx';
 import Profile from './pages/Profile.tsx';
 import Applications from './pages/Applications.tsx';
 import AdminPanel from './pages/AdminPanel.tsx';
+import VerifyEmail from './pages/VerifyEmail.tsx';
+import ForgotPassword from './pages/ForgotPassword.tsx';
+import ResetPassword from './pages/ResetPassword.tsx';
+import SavedSearchJobs from './pages/SavedSearchJobs.tsx';
 import { AuthProvider } from './contexts/AuthContext.tsx';
 
 function App() {
@@ -22,9 +26,13 @@ function App() {
           <Route path="/" element={<Home />} />
           <Route path="/login" element={<Login />} />
           <Route path="/register" element={<Register />} />
+          <Route path="/verify-email" element={<VerifyEmail />} />
+          <Route path="/forgot-password" element={<ForgotPassword />} />
+          <Route path="/reset-password" element={<ResetPassword />} />
           <Route path="/jobs" element={<Jobs />} />
           <Route path="/jobs/:id" element={<JobDetails />} />
           <Route path="/create-job" element={<CreateJob />} />
+          <Route path="/saved-searches/:id" element={<SavedSearchJobs />} />
           <Route path="/profile" element={<Profile />} />
           <Route path="/applications" element={<Applications />} />
           <Route path="/admin" element={<AdminPanel />} />
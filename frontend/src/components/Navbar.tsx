@@ -17,6 +17,7 @@ import {
 } from '@mui/icons-material';
 import { useNavigate } from 'react-router-dom';
 import { useAuth } from '../contexts/AuthContext.tsx';
+import NotificationBell from './NotificationBell.tsx';
 
 const Navbar: React.FC = () => {
   const { user, logout } = useAuth();
@@ -93,6 +94,8 @@ const Navbar: React.FC = () => {
                 </Button>
               )}
 
+              <NotificationBell />
+
               <IconButton
                 size="large"
                 aria-label="account of current user"
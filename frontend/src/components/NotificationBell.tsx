@@ -0,0 +1,121 @@
+import React from 'react';
+import {
+  Badge,
+  IconButton,
+  Menu,
+  MenuItem,
+  ListItemText,
+  Typography,
+} from '@mui/material';
+import { Notifications as NotificationsIcon } from '@mui/icons-material';
+import { useNavigate } from 'react-router-dom';
+import api from '../services/api.ts';
+import { Notification } from '../types/index.ts';
+
+// Колокольчик с числом непрочитанных уведомлений, обновляется через SSE
+const NotificationBell: React.FC = () => {
+  const navigate = useNavigate();
+  const [unread, setUnread] = React.useState(0);
+  const [items, setItems] = React.useState<Notification[]>([]);
+  const [anchorEl, setAnchorEl] = React.useState<null | HTMLElement>(null);
+
+  React.useEffect(() => {
+    let source: EventSource | null = null;
+    let retry: ReturnType<typeof setTimeout> | undefined;
+    let stopped = false;
+
+    const connect = () => {
+      const token = localStorage.getItem('token');
+      if (!token || stopped) {
+        return;
+      }
+
+      source = new EventSource(`/api/notifications/stream?access_token=${encodeURIComponent(token)}`);
+      source.addEventListener('unread', (event) => {
+        setUnread(JSON.parse((event as MessageEvent).data).unread);
+      });
+      source.addEventListener('notification', (event) => {
+        const notification: Notification = JSON.parse((event as MessageEvent).data);
+        setItems((prev) => [notification, ...prev].slice(0, 10));
+      });
+      // EventSource не переподключается после 401 и всегда шлёт старый токен,
+      // поэтому переподключаемся сами: запрос через api обновит истёкший токен
+      source.onerror = () => {
+        source?.close();
+        retry = setTimeout(async () => {
+          try {
+            const response = await api.get('/notifications/unread-count');
+            setUnread(response.data.unread);
+          } catch (error) {
+            console.error('Error fetching notifications:', error);
+          }
+          connect();
+        }, 3000);
+      };
+    };
+
+    connect();
+    return () => {
+      stopped = true;
+      clearTimeout(retry);
+      source?.close();
+    };
+  }, []);
+
+  const handleOpen = async (event: React.MouseEvent<HTMLElement>) => {
+    setAnchorEl(event.currentTarget);
+    try {
+      const response = await api.get('/notifications', { params: { limit: 10 } });
+      setItems(response.data.notifications);
+      if (response.data.unread > 0) {
+        await api.post('/notifications/read-all');
+      }
+    } catch (error) {
+      console.error('Error fetching notifications:', error);
+    }
+  };
+
+  const handleClick = (notification: Notification) => {
+    setAnchorEl(null);
+    if (notification.link) {
+      navigate(notification.link);
+    }
+  };
+
+  return (
+    <>
+      <IconButton size="large" color="inherit" aria-label="notifications" onClick={handleOpen}>
+        <Badge badgeContent={unread} color="error">
+          <NotificationsIcon />
+        </Badge>
+      </IconButton>
+      <Menu
+        anchorEl={anchorEl}
+        open={Boolean(anchorEl)}
+        onClose={() => setAnchorEl(null)}
+        PaperProps={{ sx: { width: 360, maxHeight: 480 } }}
+      >
+        {items.length === 0 && (
+          <MenuItem disabled>
+            <Typography variant="body2">Нет уведомлений</Typography>
+          </MenuItem>
+        )}
+        {items.map((notification) => (
+          <MenuItem
+            key={notification.id}
+            onClick={() => handleClick(notification)}
+            sx={{ whiteSpace: 'normal', fontWeight: notification.read_at ? 'normal' : 'bold' }}
+          >
+            <ListItemText
+              primary={notification.title}
+              secondary={notification.body}
+              primaryTypographyProps={{ fontWeight: 'inherit' }}
+            />
+          </MenuItem>
+        ))}
+      </Menu>
+    </>
+  );
+};
+
+export default NotificationBell;
@@ -6,7 +6,8 @@ interface AuthContextType {
   user: User | null;
   token: string | null;
   login: (email: string, password: string) => Promise<void>;
-  register: (name: string, email: string, password: string, role?: string) => Promise<void>;
+  // Resolves to false when the account must confirm its email before logging in
+  register: (name: string, email: string, password: string, role?: string) => Promise<boolean>;
   logout: () => void;
   loading: boolean;
 }
@@ -41,6 +42,7 @@ export const AuthProvider: React.FC<AuthProviderProps> = ({ children }) => {
           setToken(storedToken);
         } catch (error) {
           localStorage.removeItem('token');
+          localStorage.removeItem('refresh_token');
           delete api.defaults.headers.common['Authorization'];
         }
       }
@@ -53,35 +55,45 @@ export const AuthProvider: React.FC<AuthProviderProps> = ({ children }) => {
   const login = async (email: string, password: string) => {
     try {
       const response = await api.post('/auth/login', { email, password });
-      const { token: newToken, user: userData } = response.data;
+      const { token: newToken, refresh_token: refreshToken, user: userData } = response.data;
       
       localStorage.setItem('token', newToken);
+      localStorage.setItem('refresh_token', refreshToken);
       api.defaults.headers.common['Authorization'] = `Bearer ${newToken}`;
       
       setToken(newToken);
       setUser(userData);
     } catch (error: any) {
-      throw new Error(error.response?.data?.error || 'Login failed');
+      const loginError: Error & { status?: number } = new Error(error.response?.data?.error || 'Login failed');
+      loginError.status = error.response?.status;
+      throw loginError;
     }
   };
 
   const register = async (name: string, email: string, password: string, role = 'job_seeker') => {
     try {
       const response = await api.post('/auth/register', { name, email, password, role });
-      const { token: newToken, user: userData } = response.data;
-      
+      const { token: newToken, refresh_token: refreshToken, user: userData } = response.data;
+      if (!newToken) {
+        return false;
+      }
+
       localStorage.setItem('token', newToken);
+      localStorage.setItem('refresh_token', refreshToken);
       api.defaults.headers.common['Authorization'] = `Bearer ${newToken}`;
       
       setToken(newToken);
       setUser(userData);
+      return true;
     } catch (error: any) {
       throw new Error(error.response?.data?.error || 'Registration failed');
     }
   };
 
   const logout = () => {
+    api.post('/auth/logout').catch(() => undefined);
     localStorage.removeItem('token');
+    localStorage.removeItem('refresh_token');
     delete api.defaults.headers.common['Authorization'];
     setToken(null);
     setUser(null);
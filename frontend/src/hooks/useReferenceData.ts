@@ -0,0 +1,37 @@
+import { useEffect, useState } from 'react';
+import { Category, EmploymentType } from '../types/index.ts';
+import api from '../services/api.ts';
+
+export interface CategoryOption {
+  slug: string;
+  name: string;
+  depth: number;
+}
+
+const flattenCategories = (categories: Category[], depth = 0): CategoryOption[] =>
+  categories.flatMap((category) => [
+    { slug: category.slug, name: category.name, depth },
+    ...flattenCategories(category.children || [], depth + 1),
+  ]);
+
+// Loads the job categories (flattened tree order) and employment types
+export const useReferenceData = () => {
+  const [categories, setCategories] = useState<CategoryOption[]>([]);
+  const [employmentTypes, setEmploymentTypes] = useState<EmploymentType[]>([]);
+
+  useEffect(() => {
+    api.get('/categories').then((response) => {
+      setCategories(flattenCategories(response.data.categories));
+    }).catch(() => setCategories([]));
+    api.get('/employment-types').then((response) => {
+      setEmploymentTypes(response.data.employment_types);
+    }).catch(() => setEmploymentTypes([]));
+  }, []);
+
+  const categoryName = (slug: string) =>
+    categories.find((category) => category.slug === slug)?.name || slug;
+  const employmentTypeName = (slug: string) =>
+    employmentTypes.find((type) => type.slug === slug)?.name || slug;
+
+  return { categories, employmentTypes, categoryName, employmentTypeName };
+};
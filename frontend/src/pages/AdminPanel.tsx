@@ -43,11 +43,11 @@ const AdminPanel: React.FC = () => {
       try {
         setLoading(true);
         // Получаем все заявки
-        const applicationsResponse = await api.get('/applications/all');
+        const applicationsResponse = await api.get('/applications/all?limit=100');
         setApplications(applicationsResponse.data.applications);
         
         // Получаем все вакансии
-        const jobsResponse = await api.get('/jobs/all');
+        const jobsResponse = await api.get('/jobs/all?limit=100');
         setJobs(jobsResponse.data.jobs);
       } catch (err: any) {
         setError('Ошибка загрузки данных');
@@ -87,9 +87,11 @@ const AdminPanel: React.FC = () => {
 
   const getStatusColor = (status: string) => {
     switch (status) {
-      case 'accepted':
+      case 'offer':
+      case 'hired':
         return 'success';
       case 'rejected':
+      case 'withdrawn':
         return 'error';
       default:
         return 'default';
@@ -98,12 +100,20 @@ const AdminPanel: React.FC = () => {
 
   const getStatusText = (status: string) => {
     switch (status) {
-      case 'pending':
+      case 'applied':
         return 'На рассмотрении';
-      case 'accepted':
-        return 'Принята';
+      case 'screening':
+        return 'Отбор';
+      case 'interview':
+        return 'Собеседование';
+      case 'offer':
+        return 'Предложение';
+      case 'hired':
+        return 'Принят на работу';
       case 'rejected':
         return 'Отклонена';
+      case 'withdrawn':
+        return 'Отозвана';
       default:
         return status;
     }
@@ -174,7 +184,7 @@ const AdminPanel: React.FC = () => {
                 На рассмотрении
               </Typography>
               <Typography variant="h4">
-                {applications.filter(app => app.status === 'pending').length}
+                {applications.filter(app => app.status === 'applied').length}
               </Typography>
             </CardContent>
           </Card>
@@ -186,7 +196,7 @@ const AdminPanel: React.FC = () => {
                 Принятые
               </Typography>
               <Typography variant="h4">
-                {applications.filter(app => app.status === 'accepted').length}
+                {applications.filter(app => app.status === 'offer' || app.status === 'hired').length}
               </Typography>
             </CardContent>
           </Card>
@@ -277,8 +287,10 @@ const AdminPanel: React.FC = () => {
               label="Статус"
               onChange={(e) => setNewStatus(e.target.value)}
             >
-              <MenuItem value="pending">На рассмотрении</MenuItem>
-              <MenuItem value="accepted">Принята</MenuItem>
+              <MenuItem value="screening">Отбор</MenuItem>
+              <MenuItem value="interview">Собеседование</MenuItem>
+              <MenuItem value="offer">Предложение</MenuItem>
+              <MenuItem value="hired">Принят на работу</MenuItem>
               <MenuItem value="rejected">Отклонена</MenuItem>
             </Select>
           </FormControl>
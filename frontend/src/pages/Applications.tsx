@@ -84,9 +84,11 @@ const Applications: React.FC = () => {
 
   const getStatusColor = (status: string) => {
     switch (status) {
-      case 'accepted':
+      case 'offer':
+      case 'hired':
         return 'success';
       case 'rejected':
+      case 'withdrawn':
         return 'error';
       default:
         return 'default';
@@ -95,12 +97,20 @@ const Applications: React.FC = () => {
 
   const getStatusText = (status: string) => {
     switch (status) {
-      case 'pending':
+      case 'applied':
         return 'На рассмотрении';
-      case 'accepted':
-        return 'Принята';
+      case 'screening':
+        return 'Отбор';
+      case 'interview':
+        return 'Собеседование';
+      case 'offer':
+        return 'Предложение';
+      case 'hired':
+        return 'Принят на работу';
       case 'rejected':
         return 'Отклонена';
+      case 'withdrawn':
+        return 'Отозвана';
       default:
         return status;
     }
@@ -256,8 +266,10 @@ const Applications: React.FC = () => {
                 label="Статус"
                 onChange={(e) => setNewStatus(e.target.value)}
               >
-                <MenuItem value="pending">На рассмотрении</MenuItem>
-                <MenuItem value="accepted">Принята</MenuItem>
+                <MenuItem value="screening">Отбор</MenuItem>
+                <MenuItem value="interview">Собеседование</MenuItem>
+                <MenuItem value="offer">Предложение</MenuItem>
+                <MenuItem value="hired">Принят на работу</MenuItem>
                 <MenuItem value="rejected">Отклонена</MenuItem>
               </Select>
             </FormControl>
@@ -14,12 +14,14 @@ import {
 import { useNavigate } from 'react-router-dom';
 import { useAuth } from '../contexts/AuthContext.tsx';
 import api from '../services/api.ts';
+import { useReferenceData } from '../hooks/useReferenceData.ts';
 
 const CreateJob: React.FC = () => {
   const { user } = useAuth();
   const navigate = useNavigate();
   const [loading, setLoading] = useState(false);
   const [error, setError] = useState('');
+  const { categories, employmentTypes } = useReferenceData();
   const [formData, setFormData] = useState({
     title: '',
     description: '',
@@ -127,9 +129,9 @@ const CreateJob: React.FC = () => {
                 label="Тип работы"
                 onChange={(e) => handleChange('type', e.target.value)}
               >
-                <MenuItem value="full-time">Полная занятость</MenuItem>
-                <MenuItem value="part-time">Частичная занятость</MenuItem>
-                <MenuItem value="contract">Контракт</MenuItem>
+                {employmentTypes.map((type) => (
+                  <MenuItem key={type.slug} value={type.slug}>{type.name}</MenuItem>
+                ))}
               </Select>
             </FormControl>
 
@@ -140,12 +142,11 @@ const CreateJob: React.FC = () => {
                 label="Категория"
                 onChange={(e) => handleChange('category', e.target.value)}
               >
-                <MenuItem value="IT">IT</MenuItem>
-                <MenuItem value="Marketing">Маркетинг</MenuItem>
-                <MenuItem value="Sales">Продажи</MenuItem>
-                <MenuItem value="Finance">Финансы</MenuItem>
-                <MenuItem value="HR">HR</MenuItem>
-                <MenuItem value="Other">Другое</MenuItem>
+                {categories.map((category) => (
+                  <MenuItem key={category.slug} value={category.slug} sx={{ pl: 2 + category.depth * 2 }}>
+                    {category.name}
+                  </MenuItem>
+                ))}
               </Select>
             </FormControl>
           </Box>
@@ -0,0 +1,85 @@
+import React, { useState } from 'react';
+import {
+  Container,
+  Paper,
+  TextField,
+  Button,
+  Typography,
+  Box,
+  Alert,
+  Link,
+} from '@mui/material';
+import { Link as RouterLink } from 'react-router-dom';
+import api from '../services/api.ts';
+
+const ForgotPassword: React.FC = () => {
+  const [email, setEmail] = useState('');
+  const [error, setError] = useState('');
+  const [sent, setSent] = useState(false);
+  const [loading, setLoading] = useState(false);
+
+  const handleSubmit = async (e: React.FormEvent) => {
+    e.preventDefault();
+    setError('');
+    setLoading(true);
+
+    try {
+      await api.post('/auth/password/forgot', { email });
+      setSent(true);
+    } catch (err: any) {
+      setError(err.response?.data?.error || 'Не удалось отправить письмо');
+    } finally {
+      setLoading(false);
+    }
+  };
+
+  return (
+    <Container component="main" maxWidth="sm">
+      <Box sx={{ marginTop: 8 }}>
+        <Paper elevation={3} sx={{ padding: 4 }}>
+          <Typography component="h1" variant="h4" align="center" gutterBottom>
+            Восстановление пароля
+          </Typography>
+
+          {error && (
+            <Alert severity="error" sx={{ mb: 2 }}>
+              {error}
+            </Alert>
+          )}
+
+          {sent ? (
+            <Alert severity="success" sx={{ mb: 2 }}>
+              Если адрес зарегистрирован, мы отправили на него ссылку для сброса пароля. Ссылка действует 1 час.
+            </Alert>
+          ) : (
+            <Box component="form" onSubmit={handleSubmit} sx={{ mt: 1 }}>
+              <TextField
+                margin="normal"
+                required
+                fullWidth
+                id="email"
+                label="Email"
+                name="email"
+                autoComplete="email"
+                autoFocus
+                value={email}
+                onChange={(e) => setEmail(e.target.value)}
+              />
+              <Button type="submit" fullWidth variant="contained" sx={{ mt: 3, mb: 2 }} disabled={loading}>
+                {loading ? 'Отправка...' : 'Отправить ссылку'}
+              </Button>
+            </Box>
+          )}
+
+          <Box textAlign="center">
+            <Link component={RouterLink} to="/login" variant="body2">
+              Вернуться ко входу
+            </Link>
+          </Box>
+        </Paper>
+      </Box>
+    </Container>
+  );
+};
+
+export default ForgotPassword;
@@ -21,6 +21,7 @@ import { useNavigate } from 'react-router-dom';
 import { Job } from '../types/index.ts';
 import { useAuth } from '../contexts/AuthContext.tsx';
 import api from '../services/api.ts';
+import { useReferenceData } from '../hooks/useReferenceData.ts';
 
 const Jobs: React.FC = () => {
   const { user } = useAuth();
@@ -29,6 +30,7 @@ const Jobs: React.FC = () => {
   const [error, setError] = useState('');
   const [page, setPage] = useState(1);
   const [totalPages, setTotalPages] = useState(1);
+  const { categories, employmentTypes, categoryName, employmentTypeName } = useReferenceData();
   const [filters, setFilters] = useState({
     search: '',
     category: '',
@@ -125,12 +127,11 @@ const Jobs: React.FC = () => {
                 onChange={(e) => handleFilterChange('category', e.target.value)}
               >
                 <MenuItem value="">Все категории</MenuItem>
-                <MenuItem value="IT">IT</MenuItem>
-                <MenuItem value="Marketing">Маркетинг</MenuItem>
-                <MenuItem value="Sales">Продажи</MenuItem>
-                <MenuItem value="Finance">Финансы</MenuItem>
-                <MenuItem value="HR">HR</MenuItem>
-                <MenuItem value="Other">Другое</MenuItem>
+                {categories.map((category) => (
+                  <MenuItem key={category.slug} value={category.slug} sx={{ pl: 2 + category.depth * 2 }}>
+                    {category.name}
+                  </MenuItem>
+                ))}
               </Select>
             </FormControl>
           </Grid>
@@ -143,9 +144,9 @@ const Jobs: React.FC = () => {
                 onChange={(e) => handleFilterChange('type', e.target.value)}
               >
                 <MenuItem value="">Все типы</MenuItem>
-                <MenuItem value="full-time">Полная занятость</MenuItem>
-                <MenuItem value="part-time">Частичная занятость</MenuItem>
-                <MenuItem value="contract">Контракт</MenuItem>
+                {employmentTypes.map((type) => (
+                  <MenuItem key={type.slug} value={type.slug}>{type.name}</MenuItem>
+                ))}
               </Select>
             </FormControl>
           </Grid>
@@ -180,10 +181,10 @@ const Jobs: React.FC = () => {
                     <Chip label={job.location} size="small" variant="outlined" />
                   )}
                   {job.type && (
-                    <Chip label={job.type} size="small" variant="outlined" />
+                    <Chip label={employmentTypeName(job.type)} size="small" variant="outlined" />
                   )}
                   {job.category && (
-                    <Chip label={job.category} size="small" variant="outlined" />
+                    <Chip label={categoryName(job.category)} size="small" variant="outlined" />
                   )}
                 </Box>
                 <Typography variant="caption" color="text.secondary">
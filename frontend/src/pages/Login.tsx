@@ -16,6 +16,7 @@ const Login: React.FC = () => {
   const [email, setEmail] = useState('');
   const [password, setPassword] = useState('');
   const [error, setError] = useState('');
+  const [needsVerification, setNeedsVerification] = useState(false);
   const [loading, setLoading] = useState(false);
   
   const { login } = useAuth();
@@ -24,6 +25,7 @@ const Login: React.FC = () => {
   const handleSubmit = async (e: React.FormEvent) => {
     e.preventDefault();
     setError('');
+    setNeedsVerification(false);
     setLoading(true);
 
     try {
@@ -31,6 +33,7 @@ const Login: React.FC = () => {
       navigate('/');
     } catch (err: any) {
       setError(err.message);
+      setNeedsVerification(err.status === 403);
     } finally {
       setLoading(false);
     }
@@ -54,6 +57,14 @@ const Login: React.FC = () => {
           {error && (
             <Alert severity="error" sx={{ mb: 2 }}>
               {error}
+              {needsVerification && (
+                <>
+                  {' '}
+                  <Link component={RouterLink} to="/verify-email">
+                    Отправить письмо ещё раз
+                  </Link>
+                </>
+              )}
             </Alert>
           )}
 
@@ -96,6 +107,11 @@ const Login: React.FC = () => {
                 Нет аккаунта? Зарегистрироваться
               </Link>
             </Box>
+            <Box textAlign="center" sx={{ mt: 1 }}>
+              <Link component={RouterLink} to="/forgot-password" variant="body2">
+                Забыли пароль?
+              </Link>
+            </Box>
           </Box>
         </Paper>
       </Box>
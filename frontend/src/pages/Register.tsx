@@ -22,6 +22,7 @@ const Register: React.FC = () => {
   const [password, setPassword] = useState('');
   const [role, setRole] = useState('job_seeker');
   const [error, setError] = useState('');
+  const [awaitingConfirmation, setAwaitingConfirmation] = useState(false);
   const [loading, setLoading] = useState(false);
   
   const { register } = useAuth();
@@ -33,8 +34,11 @@ const Register: React.FC = () => {
     setLoading(true);
 
     try {
-      await register(name, email, password, role);
-      navigate('/');
+      if (await register(name, email, password, role)) {
+        navigate('/');
+      } else {
+        setAwaitingConfirmation(true);
+      }
     } catch (err: any) {
       setError(err.message);
     } finally {
@@ -63,6 +67,12 @@ const Register: React.FC = () => {
             </Alert>
           )}
 
+          {awaitingConfirmation && (
+            <Alert severity="success" sx={{ mb: 2 }}>
+              Аккаунт создан. Мы отправили на {email} письмо со ссылкой — подтвердите адрес, чтобы войти.
+            </Alert>
+          )}
+
           <Box component="form" onSubmit={handleSubmit} sx={{ mt: 1 }}>
             <TextField
               margin="normal"
@@ -0,0 +1,121 @@
+import React, { useState } from 'react';
+import {
+  Container,
+  Paper,
+  TextField,
+  Button,
+  Typography,
+  Box,
+  Alert,
+  Link,
+} from '@mui/material';
+import { useSearchParams, Link as RouterLink } from 'react-router-dom';
+import api from '../services/api.ts';
+
+// Opened from the link in the password reset email: /reset-password?token=...
+const ResetPassword: React.FC = () => {
+  const [searchParams] = useSearchParams();
+  const token = searchParams.get('token');
+  const [password, setPassword] = useState('');
+  const [confirmation, setConfirmation] = useState('');
+  const [error, setError] = useState('');
+  const [done, setDone] = useState(false);
+  const [loading, setLoading] = useState(false);
+
+  const handleSubmit = async (e: React.FormEvent) => {
+    e.preventDefault();
+    setError('');
+    if (password !== confirmation) {
+      setError('Пароли не совпадают');
+      return;
+    }
+    setLoading(true);
+
+    try {
+      await api.post('/auth/password/reset', { token, password });
+      // Every session was logged out, including this browser's
+      localStorage.removeItem('token');
+      localStorage.removeItem('refresh_token');
+      setDone(true);
+    } catch (err: any) {
+      setError(err.response?.data?.error || 'Не удалось сменить пароль');
+    } finally {
+      setLoading(false);
+    }
+  };
+
+  return (
+    <Container component="main" maxWidth="sm">
+      <Box sx={{ marginTop: 8 }}>
+        <Paper elevation={3} sx={{ padding: 4 }}>
+          <Typography component="h1" variant="h4" align="center" gutterBottom>
+            Новый пароль
+          </Typography>
+
+          {!token && (
+            <Alert severity="error" sx={{ mb: 2 }}>
+              Ссылка недействительна.{' '}
+              <Link component={RouterLink} to="/forgot-password">
+                Запросить новую
+              </Link>
+            </Alert>
+          )}
+
+          {error && (
+            <Alert severity="error" sx={{ mb: 2 }}>
+              {error}
+            </Alert>
+          )}
+
+          {done ? (
+            <>
+              <Alert severity="success" sx={{ mb: 2 }}>
+                Пароль изменён. Войдите с новым паролем.
+              </Alert>
+              <Box textAlign="center">
+                <Link component={RouterLink} to="/login" variant="body2">
+                  Войти
+                </Link>
+              </Box>
+            </>
+          ) : (
+            token && (
+              <Box component="form" onSubmit={handleSubmit} sx={{ mt: 1 }}>
+                <TextField
+                  margin="normal"
+                  required
+                  fullWidth
+                  name="password"
+                  label="Новый пароль"
+                  type="password"
+                  id="password"
+                  autoComplete="new-password"
+                  inputProps={{ minLength: 6 }}
+                  value={password}
+                  onChange={(e) => setPassword(e.target.value)}
+                />
+                <TextField
+                  margin="normal"
+                  required
+                  fullWidth
+                  name="confirmation"
+                  label="Повторите пароль"
+                  type="password"
+                  id="confirmation"
+                  autoComplete="new-password"
+                  value={confirmation}
+                  onChange={(e) => setConfirmation(e.target.value)}
+                />
+                <Button type="submit" fullWidth variant="contained" sx={{ mt: 3, mb: 2 }} disabled={loading}>
+                  {loading ? 'Сохранение...' : 'Сменить пароль'}
+                </Button>
+              </Box>
+            )
+          )}
+        </Paper>
+      </Box>
+    </Container>
+  );
+};
+
+export default ResetPassword;
@@ -0,0 +1,152 @@
+import React, { useState, useEffect } from 'react';
+import {
+  Box,
+  Typography,
+  Grid,
+  Card,
+  CardContent,
+  CardActions,
+  Button,
+  Pagination,
+  Chip,
+  Alert,
+} from '@mui/material';
+import { useNavigate, useParams } from 'react-router-dom';
+import { Job, SavedSearch } from '../types/index.ts';
+import api from '../services/api.ts';
+import { useReferenceData } from '../hooks/useReferenceData.ts';
+
+// Opened from saved search alerts: /saved-searches/:id lists the jobs
+// currently matching the search, newest first.
+const SavedSearchJobs: React.FC = () => {
+  const { id } = useParams<{ id: string }>();
+  const [search, setSearch] = useState<SavedSearch | null>(null);
+  const [jobs, setJobs] = useState<Job[]>([]);
+  const [loading, setLoading] = useState(true);
+  const [error, setError] = useState('');
+  const [page, setPage] = useState(1);
+  const [totalPages, setTotalPages] = useState(1);
+  const { categoryName, employmentTypeName } = useReferenceData();
+
+  const navigate = useNavigate();
+
+  useEffect(() => {
+    api
+      .get('/saved-searches')
+      .then((response) => {
+        const searches: SavedSearch[] = response.data.saved_searches;
+        setSearch(searches.find((s) => s.id === Number(id)) || null);
+      })
+      .catch(() => setSearch(null));
+  }, [id]);
+
+  useEffect(() => {
+    const fetchJobs = async () => {
+      try {
+        setLoading(true);
+        setError('');
+        const params = new URLSearchParams({
+          page: page.toString(),
+          limit: '12',
+          sort: 'newest',
+        });
+
+        const response = await api.get(`/saved-searches/${id}/jobs?${params}`);
+        setJobs(response.data.jobs);
+        setTotalPages(Math.ceil(response.data.total / 12));
+      } catch (err: any) {
+        setError(err.response?.data?.error || 'Ошибка загрузки вакансий');
+      } finally {
+        setLoading(false);
+      }
+    };
+
+    fetchJobs();
+  }, [id, page]);
+
+  const formatDate = (dateString: string) => {
+    return new Date(dateString).toLocaleDateString('ru-RU');
+  };
+
+  if (loading) {
+    return (
+      <Box sx={{ display: 'flex', justifyContent: 'center', mt: 4 }}>
+        <Typography>Загрузка...</Typography>
+      </Box>
+    );
+  }
+
+  return (
+    <Box>
+      <Typography variant="h4" component="h1" gutterBottom>
+        {search ? `Сохранённый поиск «${search.name}»` : 'Сохранённый поиск'}
+      </Typography>
+
+      {error && (
+        <Alert severity="error" sx={{ mb: 2 }}>
+          {error}
+        </Alert>
+      )}
+
+      <Grid container spacing={3}>
+        {jobs.map((job) => (
+          <Grid item xs={12} sm={6} md={4} key={job.id}>
+            <Card sx={{ height: '100%', display: 'flex', flexDirection: 'column' }}>
+              <CardContent sx={{ flexGrow: 1 }}>
+                <Typography variant="h6" component="h2" gutterBottom>
+                  {job.title}
+                </Typography>
+                <Typography variant="subtitle1" color="primary" gutterBottom>
+                  {job.company}
+                </Typography>
+                <Box sx={{ display: 'flex', flexWrap: 'wrap', gap: 1, mb: 2 }}>
+                  {job.location && (
+                    <Chip label={job.location} size="small" variant="outlined" />
+                  )}
+                  {job.type && (
+                    <Chip label={employmentTypeName(job.type)} size="small" variant="outlined" />
+                  )}
+                  {job.category && (
+                    <Chip label={categoryName(job.category)} size="small" variant="outlined" />
+                  )}
+                </Box>
+                <Typography variant="caption" color="text.secondary">
+                  Опубликовано: {formatDate(job.created_at)}
+                </Typography>
+              </CardContent>
+              <CardActions>
+                <Button
+                  size="small"
+                  onClick={() => navigate(`/jobs/${job.id}`)}
+                >
+                  Подробнее
+                </Button>
+              </CardActions>
+            </Card>
+          </Grid>
+        ))}
+      </Grid>
+
+      {totalPages > 1 && (
+        <Box sx={{ display: 'flex', justifyContent: 'center', mt: 4 }}>
+          <Pagination
+            count={totalPages}
+            page={page}
+            onChange={(_, newPage) => setPage(newPage)}
+            color="primary"
+          />
+        </Box>
+      )}
+
+      {jobs.length === 0 && !error && (
+        <Box sx={{ textAlign: 'center', mt: 4 }}>
+          <Typography variant="h6" color="text.secondary">
+            Вакансии не найдены
+          </Typography>
+        </Box>
+      )}
+    </Box>
+  );
+};
+
+export default SavedSearchJobs;
@@ -0,0 +1,123 @@
+import React, { useEffect, useRef, useState } from 'react';
+import {
+  Container,
+  Paper,
+  TextField,
+  Button,
+  Typography,
+  Box,
+  Alert,
+  CircularProgress,
+  Link,
+} from '@mui/material';
+import { useSearchParams, Link as RouterLink } from 'react-router-dom';
+import api from '../services/api.ts';
+
+// Opened from the link in the verification email: /verify-email?token=...
+// Without a token, or when it has expired, a new link can be requested.
+const VerifyEmail: React.FC = () => {
+  const [searchParams] = useSearchParams();
+  const token = searchParams.get('token');
+  const [status, setStatus] = useState<'confirming' | 'confirmed' | 'failed' | 'idle'>(token ? 'confirming' : 'idle');
+  const [error, setError] = useState('');
+  const [email, setEmail] = useState('');
+  const [message, setMessage] = useState('');
+  const [loading, setLoading] = useState(false);
+  // Tokens are single-use; StrictMode runs effects twice in development
+  const submitted = useRef(false);
+
+  useEffect(() => {
+    if (!token || submitted.current) return;
+    submitted.current = true;
+
+    api
+      .post('/auth/verify-email/confirm', { token })
+      .then(() => setStatus('confirmed'))
+      .catch((err) => {
+        setError(err.response?.data?.error || 'Не удалось подтвердить адрес');
+        setStatus('failed');
+      });
+  }, [token]);
+
+  const handleResend = async (e: React.FormEvent) => {
+    e.preventDefault();
+    setError('');
+    setLoading(true);
+
+    try {
+      await api.post('/auth/verify-email/request', { email });
+      setMessage('Если адрес требует подтверждения, мы отправили на него новую ссылку.');
+    } catch (err: any) {
+      setError(err.response?.data?.error || 'Не удалось отправить письмо');
+    } finally {
+      setLoading(false);
+    }
+  };
+
+  return (
+    <Container component="main" maxWidth="sm">
+      <Box sx={{ marginTop: 8 }}>
+        <Paper elevation={3} sx={{ padding: 4 }}>
+          <Typography component="h1" variant="h4" align="center" gutterBottom>
+            Подтверждение email
+          </Typography>
+
+          {status === 'confirming' && (
+            <Box textAlign="center" sx={{ py: 2 }}>
+              <CircularProgress />
+            </Box>
+          )}
+
+          {status === 'confirmed' && (
+            <>
+              <Alert severity="success" sx={{ mb: 2 }}>
+                Адрес электронной почты подтверждён.
+              </Alert>
+              <Box textAlign="center">
+                <Link component={RouterLink} to="/login" variant="body2">
+                  Войти
+                </Link>
+              </Box>
+            </>
+          )}
+
+          {(status === 'failed' || status === 'idle') && (
+            <>
+              {error && (
+                <Alert severity="error" sx={{ mb: 2 }}>
+                  {error}
+                </Alert>
+              )}
+              {message && (
+                <Alert severity="success" sx={{ mb: 2 }}>
+                  {message}
+                </Alert>
+              )}
+              <Typography variant="body2" color="text.secondary">
+                Укажите email, и мы отправим новую ссылку для подтверждения.
+              </Typography>
+              <Box component="form" onSubmit={handleResend} sx={{ mt: 1 }}>
+                <TextField
+                  margin="normal"
+                  required
+                  fullWidth
+                  id="email"
+                  label="Email"
+                  name="email"
+                  autoComplete="email"
+                  value={email}
+                  onChange={(e) => setEmail(e.target.value)}
+                />
+                <Button type="submit" fullWidth variant="contained" sx={{ mt: 3, mb: 2 }} disabled={loading}>
+                  {loading ? 'Отправка...' : 'Отправить ссылку'}
+                </Button>
+              </Box>
+            </>
+          )}
+        </Paper>
+      </Box>
+    </Container>
+  );
+};
+
+export default VerifyEmail;
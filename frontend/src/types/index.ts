@@ -13,6 +13,11 @@ export interface UserProfile {
   user_id: number;
   phone?: string;
   location?: string;
+  work_mode?: '' | WorkMode;
+  city?: string;
+  country?: string;
+  latitude?: number | null;
+  longitude?: number | null;
   experience?: string;
   skills?: string;
   education?: string;
@@ -21,12 +26,20 @@ export interface UserProfile {
   updated_at: string;
 }
 
+export type WorkMode = 'onsite' | 'remote' | 'hybrid';
+
 export interface Job {
   id: number;
   title: string;
   description: string;
   company: string;
   location?: string;
+  work_mode: WorkMode;
+  city?: string;
+  country?: string;
+  latitude?: number | null;
+  longitude?: number | null;
+  distance_km?: number;
   salary?: string;
   type?: string;
   category?: string;
@@ -35,6 +48,12 @@ export interface Job {
   employer_id: number;
   employer: User;
   is_active: boolean;
+  is_saved?: boolean;
+  publish_at?: string | null;
+  expires_at?: string | null;
+  max_applications?: number | null;
+  closed_at?: string | null;
+  close_reason?: 'manual' | 'expired' | 'max_applications';
   created_at: string;
   updated_at: string;
 }
@@ -45,7 +64,7 @@ export interface JobApplication {
   job: Job;
   user_id: number;
   user: User;
-  status: 'pending' | 'accepted' | 'rejected';
+  status: 'applied' | 'screening' | 'interview' | 'offer' | 'hired' | 'rejected' | 'withdrawn';
   message?: string;
   created_at: string;
   updated_at: string;
@@ -63,6 +82,45 @@ export interface PaginatedResponse<T> {
   limit: number;
 }
 
+export interface Notification {
+  id: number;
+  user_id: number;
+  type: 'saved_search' | 'application_received' | 'application_status' | 'application_withdrawn' | 'job_closed';
+  title: string;
+  body?: string;
+  link?: string;
+  read_at?: string | null;
+  created_at: string;
+}
 
+export interface SavedSearch {
+  id: number;
+  user_id: number;
+  name: string;
+  filters: Record<string, unknown>;
+  frequency: 'off' | 'hourly' | 'daily' | 'weekly';
+  email_alerts: boolean;
+  alerts_checked_at: string;
+  created_at: string;
+  updated_at: string;
+}
 
+export interface Category {
+  id: number;
+  slug: string;
+  parent_id?: number | null;
+  name: string;
+  name_ru: string;
+  name_en: string;
+  position: number;
+  children?: Category[];
+}
 
+export interface EmploymentType {
+  id: number;
+  slug: string;
+  name: string;
+  name_ru: string;
+  name_en: string;
+  position: number;
+}